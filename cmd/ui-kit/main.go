@@ -1,19 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	"log"
+	"math"
 	"os"
+	"strings"
 	"time"
 	"uikit/uikit"
 
 	"gioui.org/app"
 	"gioui.org/layout"
 	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
 	"gioui.org/unit"
 	"gioui.org/widget"
-	"gioui.org/widget/material"
 )
 
 type App struct {
@@ -21,10 +26,14 @@ type App struct {
 	kit    *uikit.UIKit
 
 	// Form fields
-	nameEditor    widget.Editor
-	emailEditor   widget.Editor
-	messageEditor widget.Editor
-	slider        widget.Float
+	contact     ContactForm
+	contactForm *uikit.Binder
+
+	// Sliders
+	volumeSlider uikit.Slider
+	ratingSlider uikit.Slider
+	priceRange   uikit.Slider
+	levelSlider  uikit.Slider
 
 	// Buttons
 	primaryBtn   widget.Clickable
@@ -33,27 +42,88 @@ type App struct {
 	ghostBtn     widget.Clickable
 	dangerBtn    widget.Clickable
 	successBtn   widget.Clickable
+	disabledBtn  widget.Clickable
+	loadingBtn   widget.Clickable
+	settingsBtn  widget.Clickable
+	fabBtn       widget.Clickable
+	composeBtn   widget.Clickable
+
+	// Cards
+	profileCard widget.Clickable
+	followBtn   widget.Clickable
+	moreBtn     widget.Clickable
+
+	// Inputs
+	usernameInput widget.Editor
+	clearUsername widget.Clickable
+	amountInput   widget.Editor
+	websiteInput  widget.Editor
+	bioInput      widget.Editor
+	apiKeyInput   widget.Editor
+	lockedInput   widget.Editor
+	passwordInput uikit.PasswordInput
+	quantityInput uikit.NumberInput
+	priceInput    uikit.NumberInput
+	searchInput   uikit.SearchInput
+	searches      int
+	phoneInput    uikit.MaskedInput
+	citySelect    uikit.Select
+	skillSelect   uikit.Select
+	planSelect    uikit.Select
+	assigneeBox   uikit.Combobox
+	labelBox      uikit.Combobox
 
 	// Checkboxes
-	checkbox1 widget.Bool
-	checkbox2 widget.Bool
-	checkbox3 widget.Bool
+	allOptions widget.Bool
+	checkbox1  widget.Bool
+	checkbox2  widget.Bool
+	checkbox3  widget.Bool
+	// Always checked and disabled
+	lockedOption widget.Bool
+
+	// Appearance
+	themeMode    widget.Enum
+	reduceMotion widget.Bool
+
+	// Navigation
+	tabs uikit.Tabs
+
+	// Dialogs
+	modal         uikit.Modal
+	confirmDelete *uikit.Dialog
+
+	// Notifications
+	toaster   uikit.Toaster
+	undoReset uikit.ToastID
+	resetForm ContactForm
+	tip       uikit.AlertState
+	undoTip   uikit.ToastID
 
 	// Interactive elements
 	submitBtn widget.Clickable
 	resetBtn  widget.Clickable
 
 	// State
-	progress         float32
-	notification     string
-	notificationType uikit.AlertVariant
-	showNotification bool
-	formSubmitted    bool
-	selectedTab      int
+	progress      float32
+	formSubmitted bool
+	submitPending bool // Submit was pressed while the email was being checked
+	loadingUntil  time.Time
 
 	// Animation
 	animationStart time.Time
-	lastFrame      time.Time
+	progressBar    uikit.ProgressState
+	progressRing   uikit.ProgressState
+}
+
+// ContactForm is edited in the form tab, which is generated from its tags
+type ContactForm struct {
+	Name       string `ui:"label=Name,hint=Enter your full name,validate=required|maxlen:80"`
+	Email      string `ui:"label=Email,hint=your.email@example.com,helper=We only use it to reply,icon=mail,validate=required|email"`
+	Topic      string `ui:"label=Topic,options=General|Support|Sales"`
+	Country    string `ui:"label=Country,hint=Where you are,options=Canada|France|Germany|Japan|Mexico|United States"`
+	Message    string `ui:"label=Message,hint=Type your message here...,helper=Ctrl+Enter sends the message,multiline,validate=required|minlen:10|maxlen:500"`
+	Urgency    int    `ui:"label=Urgency,min=1,max=5"`
+	Newsletter bool   `ui:"label=Send me product news"`
 }
 
 func NewApp() *App {
@@ -61,91 +131,244 @@ func NewApp() *App {
 		kit:            uikit.NewUIKit(),
 		progress:       0.0,
 		animationStart: time.Now(),
-		lastFrame:      time.Now(),
-		slider:         widget.Float{Value: 0.5},
-		selectedTab:    0,
+		volumeSlider:   uikit.Slider{Value: 50},
+		ratingSlider:   uikit.Slider{Value: 3},
+		priceRange:     uikit.Slider{Value: 200, High: 600},
+		levelSlider:    uikit.Slider{Value: 0.7},
 	}
+	app.themeMode.Value = "light"
+	app.lockedOption.Value = true
+	app.reduceMotion.Value = app.kit.Motion.Reduced
+
+	app.usernameInput.SingleLine = true
+	app.amountInput.SingleLine = true
+	app.websiteInput.SingleLine = true
+	app.websiteInput.SetText("example")
+	app.bioInput.SingleLine = true
+	app.apiKeyInput.SingleLine = true
+	app.apiKeyInput.SetText("sk-live-4f9a2c")
+	app.lockedInput.SingleLine = true
+	app.lockedInput.SetText("Managed by your organization")
+	app.quantityInput.SetValue(1)
+	app.priceInput.SetValue(1249.5)
+	app.searchInput.OnSearch = func(string) { app.searches++ }
+	app.phoneInput.Mask = uikit.MaskPhone
+	app.skillSelect.Values = []string{"go"}
+	app.planSelect.Value = "team"
+	app.assigneeBox.Suggest = lookupUsers
+	app.labelBox.Suggest = suggestLabels
+	app.labelBox.Debounce = 50 * time.Millisecond
 
-	// Set up initial editor content
-	app.nameEditor.SetText("John Doe")
-	app.emailEditor.SetText("john@example.com")
-	app.messageEditor.SetText("This is a sample message to demonstrate the multi-line text editor component.")
+	// Set up initial form content
+	app.contact = ContactForm{
+		Name:    "John Doe",
+		Email:   "john@example.com",
+		Topic:   "General",
+		Message: "This is a sample message to demonstrate the multi-line text editor component.",
+		Urgency: 3,
+	}
+	var err error
+	if app.contactForm, err = app.kit.Bind(&app.contact); err != nil {
+		log.Fatal(err)
+	}
+	app.contactForm.Form.Lookup("Email").Async = checkEmailAvailable
 
 	return app
 }
 
+// checkEmailAvailable stands in for a server round trip
+func checkEmailAvailable(ctx context.Context, email string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(500 * time.Millisecond):
+	}
+	if strings.HasPrefix(email, "admin@") {
+		return errors.New("This address is already registered")
+	}
+	return nil
+}
+
+// directory stands in for a user service
+var directory = []uikit.Suggestion{
+	{Key: "u1", Label: "Ada Lovelace", Detail: "ada@example.com"},
+	{Key: "u2", Label: "Alan Turing", Detail: "alan@example.com"},
+	{Key: "u3", Label: "Barbara Liskov", Detail: "barbara@example.com"},
+	{Key: "u4", Label: "Edsger Dijkstra", Detail: "edsger@example.com"},
+	{Key: "u5", Label: "Grace Hopper", Detail: "grace@example.com"},
+	{Key: "u6", Label: "Ken Thompson", Detail: "ken@example.com"},
+	{Key: "u7", Label: "Margaret Hamilton", Detail: "margaret@example.com"},
+	{Key: "u8", Label: "Rob Pike", Detail: "rob@example.com"},
+}
+
+// lookupUsers finds users by name or email after a server's delay
+func lookupUsers(ctx context.Context, query string) ([]uikit.Suggestion, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(600 * time.Millisecond):
+	}
+	query = strings.ToLower(query)
+	var found []uikit.Suggestion
+	for _, u := range directory {
+		if strings.Contains(strings.ToLower(u.Label), query) || strings.Contains(u.Detail, query) {
+			found = append(found, u)
+		}
+	}
+	return found, nil
+}
+
+// suggestLabels completes issue labels from a fixed list
+func suggestLabels(_ context.Context, query string) ([]uikit.Suggestion, error) {
+	var found []uikit.Suggestion
+	for _, l := range []string{"bug", "design", "docs", "enhancement", "good first issue", "performance", "question"} {
+		if strings.Contains(l, strings.ToLower(query)) {
+			found = append(found, uikit.Suggestion{Key: l, Label: l})
+		}
+	}
+	return found, nil
+}
+
 func (a *App) handleEvents(gtx layout.Context) {
+	a.contactForm.Update(gtx)
+
 	// Handle button clicks
 	if a.primaryBtn.Clicked(gtx) {
-		a.showNotification = true
-		a.notification = "Primary button clicked!"
-		a.notificationType = uikit.AlertInfo
+		a.toaster.Push(uikit.Toast{Message: "Primary button clicked!", Variant: uikit.AlertInfo})
 	}
 
 	if a.secondaryBtn.Clicked(gtx) {
-		a.showNotification = true
-		a.notification = "Secondary action performed"
-		a.notificationType = uikit.AlertSuccess
+		// Report back from a background goroutine, as real work would
+		go func() {
+			time.Sleep(time.Second)
+			a.toaster.Push(uikit.Toast{Message: "Secondary action performed", Variant: uikit.AlertSuccess})
+		}()
 	}
 
 	if a.outlineBtn.Clicked(gtx) {
-		a.showNotification = true
-		a.notification = "Outline button pressed"
-		a.notificationType = uikit.AlertWarning
+		a.toaster.Push(uikit.Toast{Message: "Outline button pressed", Variant: uikit.AlertWarning})
 	}
 
 	if a.dangerBtn.Clicked(gtx) {
-		a.showNotification = true
-		a.notification = "Danger! This is a destructive action"
-		a.notificationType = uikit.AlertError
+		a.confirmDelete = uikit.Confirm("Delete item?", "This permanently removes the item and cannot be undone.", "Delete", true)
+		a.modal.Show(a.confirmDelete)
+	}
+
+	for {
+		result, ok := a.modal.Update(gtx)
+		if !ok {
+			break
+		}
+		if result.Dialog == a.confirmDelete && !result.Cancelled() {
+			a.toaster.Push(uikit.Toast{Message: "Danger! The item was deleted", Variant: uikit.AlertError})
+		}
+	}
+
+	if a.fabBtn.Clicked(gtx) || a.composeBtn.Clicked(gtx) {
+		a.tabs.Select(1)
+	}
+
+	if a.profileCard.Clicked(gtx) {
+		a.toaster.Push(uikit.Toast{Message: "Opening profile", Variant: uikit.AlertInfo})
+	}
+
+	if a.followBtn.Clicked(gtx) {
+		a.toaster.Push(uikit.Toast{Message: "Following Jane Cooper", Variant: uikit.AlertSuccess})
 	}
 
 	if a.successBtn.Clicked(gtx) {
-		a.showNotification = true
-		a.notification = "Success! Operation completed"
-		a.notificationType = uikit.AlertSuccess
+		a.toaster.Push(uikit.Toast{Message: "Success! Operation completed", Variant: uikit.AlertSuccess})
 	}
 
-	if a.submitBtn.Clicked(gtx) {
+	if a.loadingBtn.Clicked(gtx) {
+		a.loadingUntil = gtx.Now.Add(2 * time.Second)
+	}
+
+	submit := a.submitBtn.Clicked(gtx)
+	if a.contactForm.Submitted(gtx) {
+		submit = true
+	}
+	if submit && a.contactForm.Form.Validating() {
+		// Wait for the email check rather than fail on it
+		a.submitPending = true
+	}
+	if a.submitPending && !a.contactForm.Form.Validating() {
+		a.submitPending, submit = false, true
+	}
+	if submit && !a.submitPending && a.contactForm.Submit(gtx) {
 		a.formSubmitted = true
-		a.showNotification = true
-		a.notification = "Form submitted successfully!"
-		a.notificationType = uikit.AlertSuccess
+		a.toaster.Push(uikit.Toast{Message: "Form submitted successfully!", Variant: uikit.AlertSuccess})
 		a.progress = 1.0
 	}
 
 	if a.resetBtn.Clicked(gtx) {
-		a.nameEditor.SetText("")
-		a.emailEditor.SetText("")
-		a.messageEditor.SetText("")
+		// The binder shows the cleared values on the next frame
+		a.resetForm = a.contact
+		a.contact.Name, a.contact.Email, a.contact.Message = "", "", ""
+		a.contact.Newsletter = false
+		a.contactForm.Form.Reset()
 		a.formSubmitted = false
+		a.submitPending = false
 		a.progress = 0.0
-		a.showNotification = true
-		a.notification = "Form reset"
-		a.notificationType = uikit.AlertInfo
+		a.animationStart = gtx.Now
+		a.undoReset = a.toaster.Push(uikit.Toast{Message: "Form cleared", Variant: uikit.AlertInfo, Action: "Undo"})
 		a.checkbox1.Value = false
 		a.checkbox2.Value = false
 		a.checkbox3.Value = false
 	}
 
-	// Handle tab clicks
-	for i := 0; i < 3; i++ {
-		if a.selectedTab != i {
-			// Create temporary buttons for tab handling
-			btn := widget.Clickable{}
-			if btn.Clicked(gtx) {
-				a.selectedTab = i
-			}
+	for {
+		id, ok := a.toaster.Update(gtx)
+		if !ok {
+			break
+		}
+		switch id {
+		case a.undoReset:
+			a.contact = a.resetForm
+		case a.undoTip:
+			a.tip.Dismissed = false
+		}
+	}
+
+	for {
+		e, ok := a.tip.Update(gtx)
+		if !ok {
+			break
+		}
+		switch e.Kind {
+		case uikit.AlertDismissed:
+			a.undoTip = a.toaster.Push(uikit.Toast{Message: "Tip hidden", Variant: uikit.AlertInfo, Action: "Undo"})
+		case uikit.AlertActionClicked:
+			a.tabs.Select(2)
 		}
 	}
 
-	// Animate progress bar
-	now := time.Now()
-	if now.Sub(a.lastFrame) > time.Millisecond*50 {
-		if !a.formSubmitted && a.progress < 0.8 {
-			a.progress += 0.002
+	// Handle theme switching
+	if a.themeMode.Update(gtx) {
+		switch a.themeMode.Value {
+		case "dark":
+			a.kit.SetTheme(uikit.ThemeDark)
+		case "system":
+			a.kit.SetTheme(uikit.ThemeSystem)
+		default:
+			a.kit.SetTheme(uikit.ThemeLight)
 		}
-		a.lastFrame = now
+	}
+
+	if a.allOptions.Update(gtx) {
+		v := a.allOptions.Value
+		a.checkbox1.Value, a.checkbox2.Value, a.checkbox3.Value = v, v, v
+	}
+
+	if a.reduceMotion.Update(gtx) {
+		a.kit.Motion.Reduced = a.reduceMotion.Value
+	}
+
+	// Animate progress bar from the frame time, requesting frames until it
+	// reaches 80%
+	if !a.formSubmitted && a.progress < 0.8 {
+		a.progress = min(0.8, float32(gtx.Now.Sub(a.animationStart).Seconds()/30))
+		gtx.Execute(op.InvalidateCmd{})
 	}
 }
 
@@ -154,8 +377,9 @@ func (a *App) Layout(gtx layout.Context) layout.Dimensions {
 
 	// Use all available space
 	gtx.Constraints.Min = gtx.Constraints.Max
+	paint.Fill(gtx.Ops, a.kit.Colors.Background)
 
-	return layout.Flex{
+	dims := layout.Flex{
 		Axis:    layout.Vertical,
 		Spacing: layout.SpaceBetween,
 	}.Layout(gtx,
@@ -188,6 +412,14 @@ func (a *App) Layout(gtx layout.Context) layout.Dimensions {
 			})
 		}),
 	)
+
+	// Dialogs and toasts draw above the content. The window is tracked last
+	// so select menus can keep inside it.
+	a.kit.Modal(&a.modal)(gtx)
+	a.kit.Toasts(&a.toaster)(gtx)
+	a.kit.TrackWindow(gtx)
+
+	return dims
 }
 
 func (a *App) renderCurrentTab(gtx layout.Context) layout.Dimensions {
@@ -199,7 +431,7 @@ func (a *App) renderCurrentTab(gtx layout.Context) layout.Dimensions {
 
 	return list.Layout(gtx, 1, func(gtx layout.Context, _ int) layout.Dimensions {
 		return layout.UniformInset(a.kit.Spacing.Medium).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			switch a.selectedTab {
+			switch a.tabs.Selected {
 			case 0:
 				return a.renderComponentsTab(gtx)
 			case 1:
@@ -237,37 +469,11 @@ func (a *App) renderHeader(gtx layout.Context) layout.Dimensions {
 }
 
 func (a *App) renderTabs(gtx layout.Context) layout.Dimensions {
-	tabs := []string{"Components", "Form", "Settings"}
-
-	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return layout.Inset{Top: a.kit.Spacing.Medium}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						btn := widget.Clickable{}
-						if a.selectedTab == 0 {
-							return a.kit.Button(&btn, tabs[0], uikit.ButtonPrimary, uikit.ButtonMedium)(gtx)
-						}
-						return a.kit.Button(&btn, tabs[0], uikit.ButtonOutline, uikit.ButtonMedium)(gtx)
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						btn := widget.Clickable{}
-						if a.selectedTab == 1 {
-							return a.kit.Button(&btn, tabs[1], uikit.ButtonPrimary, uikit.ButtonMedium)(gtx)
-						}
-						return a.kit.Button(&btn, tabs[1], uikit.ButtonOutline, uikit.ButtonMedium)(gtx)
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						btn := widget.Clickable{}
-						if a.selectedTab == 2 {
-							return a.kit.Button(&btn, tabs[2], uikit.ButtonPrimary, uikit.ButtonMedium)(gtx)
-						}
-						return a.kit.Button(&btn, tabs[2], uikit.ButtonOutline, uikit.ButtonMedium)(gtx)
-					}),
-				)
-			})
-		}),
-	)
+	return layout.Inset{Top: a.kit.Spacing.Small}.Layout(gtx, a.kit.Tabs(&a.tabs, uikit.TabsUnderline,
+		uikit.Tab{Text: "Components", Icon: a.kit.Icons.Get(uikit.IconHome)},
+		uikit.Tab{Text: "Form", Icon: a.kit.Icons.Get(uikit.IconEdit)},
+		uikit.Tab{Text: "Settings", Icon: a.kit.Icons.Get(uikit.IconSettings)},
+	))
 }
 
 func (a *App) renderComponentsTab(gtx layout.Context) layout.Dimensions {
@@ -299,19 +505,233 @@ func (a *App) renderComponentsTab(gtx layout.Context) layout.Dimensions {
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return a.kit.Space(a.kit.Spacing.Medium)(gtx)
 		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.renderInputSection(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.kit.Space(a.kit.Spacing.Medium)(gtx)
+		}),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			// Progress section
 			return a.renderProgressSection(gtx)
 		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.kit.Space(a.kit.Spacing.Medium)(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.renderElevationSection(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.kit.Space(a.kit.Spacing.Medium)(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.renderCardSection(gtx)
+		}),
 	)
 }
 
-func (a *App) renderNotificationSection(gtx layout.Context) layout.Dimensions {
-	if !a.showNotification {
-		return layout.Dimensions{}
+func (a *App) renderInputSection(gtx layout.Context) layout.Dimensions {
+	kit := a.kit
+
+	username := kit.InputStyle(&a.usernameInput, "letters and digits")
+	username.Label = "Username"
+	username.LabelPlacement = uikit.InputLabelFloating
+	username.PrefixIcon = kit.Icons.Get(uikit.IconPerson)
+	username.Clear = &a.clearUsername
+
+	amount := kit.InputStyle(&a.amountInput, "0.00")
+	amount.Variant = uikit.InputFilled
+	amount.Label = "Amount"
+	amount.LabelPlacement = uikit.InputLabelFloating
+	amount.Prefix = "$"
+	amount.Suffix = "USD"
+
+	website := kit.InputStyle(&a.websiteInput, "")
+	website.Variant = uikit.InputFilled
+	website.Label = "Website"
+	website.LabelPlacement = uikit.InputLabelFloating
+	website.Prefix = "https://"
+	website.Suffix = ".com"
+	if strings.ContainsAny(a.websiteInput.Text(), " /") {
+		website.Error = "Enter a domain name only"
+	}
+
+	bio := kit.InputStyle(&a.bioInput, "A line about yourself")
+	bio.Label = "Bio"
+	bio.Helper = "Shown on your profile"
+	bio.MaxLength = 40
+
+	apiKey := kit.InputStyle(&a.apiKeyInput, "")
+	apiKey.Label = "API key"
+	apiKey.Helper = "Read-only; select to copy"
+	apiKey.ReadOnly = true
+
+	locked := kit.InputStyle(&a.lockedInput, "")
+	locked.Label = "Team"
+	locked.Disabled = true
+
+	quantity := kit.NumberInputStyle(&a.quantityInput, "Quantity", 1, 99, 1)
+	price := kit.NumberInputStyle(&a.priceInput, "Price (de-DE)", 0, math.Inf(1), 0.01)
+	price.Format = uikit.NumberFormatFor("de-DE")
+	price.Suffix = "€"
+
+	search := kit.SearchInputStyle(&a.searchInput, "Search components")
+	if q := a.searchInput.Query(); q != "" {
+		search.Helper = fmt.Sprintf("Search %d: %q", a.searches, q)
+	}
+
+	phone := kit.MaskedInputStyle(&a.phoneInput, "Phone")
+	if a.phoneInput.Len() > 0 && !a.phoneInput.Complete() && !gtx.Focused(&a.phoneInput.Editor) {
+		phone.Error = "Enter all 10 digits"
+	}
+
+	city := kit.SelectStyle(&a.citySelect, "City",
+		uikit.SelectOption{Key: "ams", Label: "Amsterdam", Group: "Europe"},
+		uikit.SelectOption{Key: "ber", Label: "Berlin", Group: "Europe"},
+		uikit.SelectOption{Key: "lis", Label: "Lisbon", Group: "Europe", Disabled: true},
+		uikit.SelectOption{Key: "par", Label: "Paris", Group: "Europe"},
+		uikit.SelectOption{Key: "bos", Label: "Boston", Group: "Americas"},
+		uikit.SelectOption{Key: "mex", Label: "Mexico City", Group: "Americas"},
+		uikit.SelectOption{Key: "tor", Label: "Toronto", Group: "Americas"},
+		uikit.SelectOption{Key: "osa", Label: "Osaka", Group: "Asia"},
+		uikit.SelectOption{Key: "sgp", Label: "Singapore", Group: "Asia"},
+		uikit.SelectOption{Key: "tyo", Label: "Tokyo", Group: "Asia"},
+	)
+	city.Hint = "Pick a city"
+	city.Helper = "Type a letter to jump to it"
+
+	skills := kit.SelectStyle(&a.skillSelect, "Skills",
+		uikit.SelectOption{Key: "go", Label: "Go"},
+		uikit.SelectOption{Key: "rust", Label: "Rust"},
+		uikit.SelectOption{Key: "ts", Label: "TypeScript"},
+		uikit.SelectOption{Key: "py", Label: "Python"},
+		uikit.SelectOption{Key: "sql", Label: "SQL"},
+	)
+	skills.Variant = uikit.InputFilled
+	skills.Multiple = true
+
+	plan := kit.SelectStyle(&a.planSelect, "Plan",
+		uikit.SelectOption{Key: "free", Label: "Free"},
+		uikit.SelectOption{Key: "team", Label: "Team"},
+	)
+	plan.Disabled = true
+
+	assignee := kit.ComboboxStyle(&a.assigneeBox, "Assignee", "Search by name or email")
+	assignee.PrefixIcon = kit.Icons.Get(uikit.IconPerson)
+	assignee.Restricted = true
+	assignee.MinChars = 2
+	if u, ok := a.assigneeBox.Selected(); ok {
+		assignee.Helper = "Assigned to " + u.Detail
+	} else {
+		assignee.Helper = "Pick someone from the directory"
+	}
+
+	label := kit.ComboboxStyle(&a.labelBox, "Label", "Any label")
+	label.Helper = "Choose a suggestion or type your own"
+
+	row := func(inputs ...layout.Widget) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			children := make([]layout.FlexChild, 0, 2*len(inputs))
+			for i, in := range inputs {
+				if i > 0 {
+					children = append(children, layout.Rigid(kit.Space(kit.Spacing.Medium)))
+				}
+				children = append(children, layout.Flexed(1, in))
+			}
+			return layout.Flex{}.Layout(gtx, children...)
+		}
 	}
+	return kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(kit.Text("Inputs", kit.Typography.HeadlineSmall, kit.Colors.TextPrimary)),
+			layout.Rigid(kit.Space(kit.Spacing.Medium)),
+			layout.Rigid(row(username.Layout, amount.Layout, website.Layout)),
+			layout.Rigid(kit.Space(kit.Spacing.Medium)),
+			layout.Rigid(row(bio.Layout, apiKey.Layout, locked.Layout)),
+			layout.Rigid(kit.Space(kit.Spacing.Medium)),
+			layout.Rigid(row(kit.PasswordInput(&a.passwordInput, "Password"), quantity.Layout, price.Layout)),
+			layout.Rigid(kit.Space(kit.Spacing.Medium)),
+			layout.Rigid(row(search.Layout, phone.Layout)),
+			layout.Rigid(kit.Space(kit.Spacing.Medium)),
+			layout.Rigid(row(city.Layout, skills.Layout, plan.Layout)),
+			layout.Rigid(kit.Space(kit.Spacing.Medium)),
+			layout.Rigid(row(assignee.Layout, label.Layout)),
+		)
+	})
+}
 
-	return a.kit.Alert("Notification", a.notification, a.notificationType)(gtx)
+func (a *App) renderCardSection(gtx layout.Context) layout.Dimensions {
+	text := func(s string) layout.Widget {
+		return a.kit.Text(s, a.kit.Typography.BodyMedium, a.kit.Colors.TextPrimary)
+	}
+
+	profile := a.kit.CardStyle(uikit.CardElevated, text("Designer at Acme. Click the card to open the profile."))
+	profile.Title = "Jane Cooper"
+	profile.Subtitle = "Product design"
+	profile.Avatar = a.kit.Icon(uikit.IconPerson, unit.Dp(40), a.kit.Colors.Primary500)
+	profile.Action = a.kit.IconButton(&a.moreBtn, a.kit.Icons.Get(uikit.IconMenu), "More", uikit.ButtonGhost, uikit.ButtonSmall)
+	profile.Media = func(gtx layout.Context) layout.Dimensions {
+		size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(80))
+		paint.FillShape(gtx.Ops, a.kit.Colors.Primary200, clip.Rect{Max: size}.Op())
+		return layout.Dimensions{Size: size}
+	}
+	verified := a.kit.BadgeStyle("Verified", uikit.BadgeSuccess)
+	verified.Icon = a.kit.Icons.Get(uikit.IconCheck)
+	profile.Footer = []layout.Widget{
+		a.kit.Button(&a.followBtn, "Follow", uikit.ButtonPrimary, uikit.ButtonSmall),
+		verified.Layout,
+	}
+	profile.Clickable = &a.profileCard
+	profile.Description = "Jane Cooper's profile"
+
+	outlined := a.kit.CardStyle(uikit.CardOutlined, text("Outlined cards sit flat on the page."))
+	outlined.Title = "Outlined"
+
+	filled := a.kit.CardStyle(uikit.CardFilled, text("Filled cards use a tinted background."))
+	filled.Title = "Filled"
+
+	return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+		layout.Flexed(1, profile.Layout),
+		layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+		layout.Flexed(1, outlined.Layout),
+		layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+		layout.Flexed(1, filled.Layout),
+	)
+}
+
+func (a *App) renderElevationSection(gtx layout.Context) layout.Dimensions {
+	return a.kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
+		levels := make([]layout.FlexChild, 0, 11)
+		for e := uikit.Elevation0; e <= uikit.Elevation5; e++ {
+			if e > 0 {
+				levels = append(levels, layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)))
+			}
+			levels = append(levels, layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return a.kit.ElevatedCard(gtx, e, a.kit.Text(fmt.Sprintf("%d", e), a.kit.Typography.TitleMedium, a.kit.Colors.TextPrimary))
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return a.kit.Text("Elevation", a.kit.Typography.HeadlineSmall, a.kit.Colors.TextPrimary)(gtx)
+			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{}.Layout(gtx, levels...)
+			}),
+		)
+	})
+}
+
+func (a *App) renderNotificationSection(gtx layout.Context) layout.Dimensions {
+	tip := a.kit.AlertStyle(&a.tip, "Notifications",
+		"Button actions report back with toasts in the bottom right corner. Toasts pause while hovered, "+
+			"stack up to three at a time and can carry an action such as Undo. Dismiss this tip to try it, "+
+			"or open the settings to switch between the light and dark themes.",
+		uikit.AlertInfo)
+	tip.Dismissible = true
+	tip.MaxLines = 1
+	tip.Actions = []string{"Open settings"}
+	return tip.Layout(gtx)
 }
 
 func (a *App) renderTypographySection(gtx layout.Context) layout.Dimensions {
@@ -379,6 +799,38 @@ func (a *App) renderButtonSection(gtx layout.Context) layout.Dimensions {
 					}),
 				)
 			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						btn := a.kit.ButtonStyle(&a.disabledBtn, "Disabled", uikit.ButtonPrimary, uikit.ButtonMedium)
+						btn.Disabled = true
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						btn := a.kit.ButtonStyle(&a.loadingBtn, "Save", uikit.ButtonPrimary, uikit.ButtonMedium)
+						btn.LeadingIcon = a.kit.Icons.Get(uikit.IconSave)
+						btn.Loading = gtx.Now.Before(a.loadingUntil)
+						if btn.Loading {
+							btn.Text = "Saving"
+						}
+						return btn.Layout(gtx)
+					}),
+					layout.Rigid(a.kit.IconButton(&a.settingsBtn, a.kit.Icons.Get(uikit.IconSettings), "Settings", uikit.ButtonOutline, uikit.ButtonMedium)),
+				)
+			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(a.kit.FAB(&a.fabBtn, a.kit.Icons.Get(uikit.IconAdd), "New message")),
+					layout.Rigid(a.kit.Space(a.kit.Spacing.Large)),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						fab := a.kit.FABStyle(&a.composeBtn, a.kit.Icons.Get(uikit.IconEdit))
+						fab.Text = "Compose"
+						return fab.Layout(gtx)
+					}),
+				)
+			}),
 		)
 	})
 }
@@ -391,14 +843,49 @@ func (a *App) renderProgressSection(gtx layout.Context) layout.Dimensions {
 			}),
 			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return a.kit.ProgressBar(a.progress)(gtx)
+				bar := a.kit.ProgressStyle(a.progress)
+				bar.Buffer = min(1, a.progress+0.15)
+				bar.Label = fmt.Sprintf("%.0f%%", a.progress*100)
+				bar.State = &a.progressBar
+				if a.formSubmitted {
+					bar.Variant = uikit.ProgressSuccess
+				}
+				return bar.Layout(gtx)
 			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return a.kit.Space(a.kit.Spacing.Small)(gtx)
+				bar := a.kit.ProgressStyle(0)
+				bar.Indeterminate = true
+				return bar.Layout(gtx)
+			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				filled := 0
+				for _, text := range []string{a.contact.Name, a.contact.Email, a.contact.Message} {
+					if text != "" {
+						filled++
+					}
+				}
+				steps := a.kit.SegmentedProgressStyle(filled, 3)
+				steps.Label = fmt.Sprintf("Form %d/3", filled)
+				if filled == 3 {
+					steps.Variant = uikit.ProgressSuccess
+				}
+				return steps.Layout(gtx)
 			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				progressText := fmt.Sprintf("%.0f%%", a.progress*100)
-				return a.kit.Text(progressText, a.kit.Typography.BodyMedium, a.kit.Colors.TextSecondary)(gtx)
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						ring := a.kit.CircularProgressStyle(a.progress)
+						ring.Size = unit.Dp(48)
+						ring.Label = fmt.Sprintf("%.0f", a.progress*100)
+						ring.State = &a.progressRing
+						return ring.Layout(gtx)
+					}),
+					layout.Rigid(a.kit.Space(a.kit.Spacing.Large)),
+					layout.Rigid(a.kit.Spinner()),
+				)
 			}),
 		)
 	})
@@ -425,67 +912,19 @@ func (a *App) renderFormTab(gtx layout.Context) layout.Dimensions {
 			}),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{Axis: layout.Vertical, Spacing: layout.SpaceBetween}.Layout(gtx,
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								return a.kit.Text("Name", a.kit.Typography.LabelMedium, a.kit.Colors.TextPrimary)(gtx)
-							}),
-							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								return a.kit.Space(a.kit.Spacing.Tiny)(gtx)
-							}),
-							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								return a.kit.Input(&a.nameEditor, "Enter your full name", false)(gtx)
-							}),
-						)
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return layout.Inset{Top: a.kit.Spacing.Medium}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							hasError := len(a.emailEditor.Text()) > 0 && !contains(a.emailEditor.Text(), "@")
-							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									return a.kit.Text("Email", a.kit.Typography.LabelMedium, a.kit.Colors.TextPrimary)(gtx)
-								}),
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									return a.kit.Space(a.kit.Spacing.Tiny)(gtx)
-								}),
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									return a.kit.Input(&a.emailEditor, "your.email@example.com", hasError)(gtx)
-								}),
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									if hasError {
-										return layout.Inset{Top: a.kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-											return a.kit.Text("Please enter a valid email address", a.kit.Typography.LabelSmall, a.kit.Colors.Error)(gtx)
-										})
-									}
-									return layout.Dimensions{}
-								}),
-							)
-						})
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return layout.Inset{Top: a.kit.Spacing.Medium}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									return a.kit.Text("Message", a.kit.Typography.LabelMedium, a.kit.Colors.TextPrimary)(gtx)
-								}),
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									return a.kit.Space(a.kit.Spacing.Tiny)(gtx)
-								}),
-								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-									// Make message field taller
-									gtx.Constraints.Min.Y = gtx.Dp(100)
-									gtx.Constraints.Max.Y = gtx.Dp(150)
-									return a.kit.Input(&a.messageEditor, "Type your message here...", false)(gtx)
-								}),
-							)
-						})
-					}),
+					layout.Rigid(a.contactForm.Layout),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Top: a.kit.Spacing.Large}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 							return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx,
 								layout.Flexed(0.48, a.kit.Button(&a.resetBtn, "Clear Form", uikit.ButtonOutline, uikit.ButtonMedium)),
 								layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
-								layout.Flexed(0.48, a.kit.Button(&a.submitBtn, "Send Message", uikit.ButtonPrimary, uikit.ButtonMedium)),
+								layout.Flexed(0.48, func(gtx layout.Context) layout.Dimensions {
+									// Once the user has tried to submit, keep the button blocked until the form is fixed
+									btn := a.kit.ButtonStyle(&a.submitBtn, "Send Message", uikit.ButtonPrimary, uikit.ButtonMedium)
+									btn.Disabled = a.contactForm.Form.Submitted() && !a.contactForm.Form.Valid() && !a.submitPending
+									btn.Loading = a.submitPending
+									return btn.Layout(gtx)
+								}),
 							)
 						})
 					}),
@@ -502,6 +941,10 @@ func (a *App) renderSettingsTab(gtx layout.Context) layout.Dimensions {
 				return a.kit.Text("Settings", a.kit.Typography.HeadlineSmall, a.kit.Colors.TextPrimary)(gtx)
 			}),
 			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return a.renderAppearanceSection(gtx)
+			}),
+			layout.Rigid(a.kit.Space(a.kit.Spacing.Medium)),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return a.renderCheckboxSection(gtx)
 			}),
@@ -513,53 +956,106 @@ func (a *App) renderSettingsTab(gtx layout.Context) layout.Dimensions {
 	})
 }
 
+func (a *App) renderAppearanceSection(gtx layout.Context) layout.Dimensions {
+	kit := a.kit
+	theme := kit.RadioGroupStyle(&a.themeMode,
+		uikit.RadioOption{Key: "light", Label: "Light"},
+		uikit.RadioOption{Key: "dark", Label: "Dark"},
+		uikit.RadioOption{Key: "system", Label: "System", Helper: "Follows the OS setting"},
+	)
+	theme.Label = "Theme"
+	theme.Axis = layout.Horizontal
+
+	motion := kit.SwitchStyle(&a.reduceMotion, "Reduce motion")
+	motion.Helper = "Turns off transitions and animations"
+
+	return kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(kit.Text("Appearance", kit.Typography.TitleMedium, kit.Colors.TextPrimary)),
+			layout.Rigid(kit.Space(kit.Spacing.Small)),
+			layout.Rigid(theme.Layout),
+			layout.Rigid(kit.Space(kit.Spacing.Small)),
+			layout.Rigid(motion.Layout),
+		)
+	})
+}
+
 func (a *App) renderCheckboxSection(gtx layout.Context) layout.Dimensions {
-	return a.kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
+	kit := a.kit
+	checked := 0
+	for _, b := range []*widget.Bool{&a.checkbox1, &a.checkbox2, &a.checkbox3} {
+		if b.Value {
+			checked++
+		}
+	}
+	all := kit.CheckboxStyle(&a.allOptions, "All options")
+	a.allOptions.Value = checked == 3
+	all.Indeterminate = checked > 0 && checked < 3
+
+	option := func(b *widget.Bool, label string) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: kit.Spacing.Large}.Layout(gtx, kit.Checkbox(b, label))
+		})
+	}
+	locked := kit.CheckboxStyle(&a.lockedOption, "Locked option")
+	locked.Helper = "Set by your administrator"
+	locked.Disabled = true
+
+	return kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return a.kit.Text("Checkbox Options", a.kit.Typography.TitleMedium, a.kit.Colors.TextPrimary)(gtx)
-			}),
-			layout.Rigid(a.kit.Space(a.kit.Spacing.Small)),
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return material.CheckBox(a.kit.Theme, &a.checkbox1, "Option 1").Layout(gtx)
-			}),
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return material.CheckBox(a.kit.Theme, &a.checkbox2, "Option 2").Layout(gtx)
-			}),
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return material.CheckBox(a.kit.Theme, &a.checkbox3, "Option 3").Layout(gtx)
-			}),
+			layout.Rigid(kit.Text("Checkbox Options", kit.Typography.TitleMedium, kit.Colors.TextPrimary)),
+			layout.Rigid(kit.Space(kit.Spacing.Small)),
+			layout.Rigid(all.Layout),
+			option(&a.checkbox1, "Option 1"),
+			option(&a.checkbox2, "Option 2"),
+			option(&a.checkbox3, "Option 3"),
+			layout.Rigid(locked.Layout),
 		)
 	})
 }
 
 func (a *App) renderSliderSection(gtx layout.Context) layout.Dimensions {
-	return a.kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
+	kit := a.kit
+	caption := func(text string) layout.FlexChild {
+		return layout.Rigid(kit.Text(text, kit.Typography.LabelMedium, kit.Colors.TextSecondary))
+	}
+
+	rating := kit.SliderStyle(&a.ratingSlider, 1, 5)
+	rating.Step = 1
+
+	price := kit.SliderStyle(&a.priceRange, 0, 1000)
+	price.Range = true
+	price.Step = 50
+	price.Format = func(v float32) string { return fmt.Sprintf("$%.0f", v) }
+
+	level := kit.SliderStyle(&a.levelSlider, 0, 1)
+	level.Axis = layout.Vertical
+	level.Format = func(v float32) string { return fmt.Sprintf("%.0f%%", v*100) }
+
+	return kit.Card(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return a.kit.Text("Slider Control", a.kit.Typography.TitleMedium, a.kit.Colors.TextPrimary)(gtx)
+				return kit.Text("Slider Control", kit.Typography.TitleMedium, kit.Colors.TextPrimary)(gtx)
 			}),
-			layout.Rigid(a.kit.Space(a.kit.Spacing.Small)),
+			layout.Rigid(kit.Space(kit.Spacing.Small)),
+			caption(fmt.Sprintf("Volume: %.0f", a.volumeSlider.Value)),
+			layout.Rigid(kit.Slider(&a.volumeSlider, 0, 100)),
+			caption(fmt.Sprintf("Rating: %.0f of 5", a.ratingSlider.Value)),
+			layout.Rigid(rating.Layout),
+			caption(fmt.Sprintf("Price: $%.0f to $%.0f", a.priceRange.Value, a.priceRange.High)),
+			layout.Rigid(price.Layout),
+			layout.Rigid(kit.Space(kit.Spacing.Small)),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return material.Slider(a.kit.Theme, &a.slider).Layout(gtx)
-			}),
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				value := fmt.Sprintf("Value: %.2f", a.slider.Value)
-				return a.kit.Text(value, a.kit.Typography.BodyMedium, a.kit.Colors.TextSecondary)(gtx)
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					caption(fmt.Sprintf("Level: %.0f%%", a.levelSlider.Value*100)),
+					layout.Rigid(kit.Space(kit.Spacing.Small)),
+					layout.Rigid(level.Layout),
+				)
 			}),
 		)
 	})
 }
 
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
 func loop(w *app.Window, a *App) error {
 	var ops op.Ops
 
@@ -582,6 +1078,10 @@ func main() {
 		w.Option(app.Size(unit.Dp(900), unit.Dp(700)))
 		a := NewApp()
 		a.window = w
+		a.toaster.Invalidate = w.Invalidate
+		a.contactForm.Form.Invalidate = w.Invalidate
+		a.assigneeBox.Invalidate = w.Invalidate
+		a.labelBox.Invalidate = w.Invalidate
 		if err := loop(w, a); err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,292 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"uikit/uikit/anim"
+)
+
+// Alert component for notifications
+type AlertVariant int
+
+const (
+	AlertInfo AlertVariant = iota
+	AlertSuccess
+	AlertWarning
+	AlertError
+)
+
+// AlertEventKind tells what the user did to an alert
+type AlertEventKind int
+
+const (
+	AlertDismissed AlertEventKind = iota
+	AlertActionClicked
+)
+
+// AlertEvent is reported by AlertState.Update
+type AlertEvent struct {
+	Kind AlertEventKind
+	// Action is the index of the clicked action, for AlertActionClicked
+	Action int
+}
+
+// AlertState holds the persistent state of an interactive alert. A
+// dismissed alert fades and folds away, then lays out as nothing until
+// Dismissed is cleared.
+type AlertState struct {
+	Dismissed bool
+	// Expanded shows the whole of a collapsible message
+	Expanded bool
+
+	visible anim.Tween
+	close   widget.Clickable
+	toggle  widget.Clickable
+	actions []*widget.Clickable
+	events  []AlertEvent
+}
+
+// Update returns the next dismiss or action event since the last call
+func (s *AlertState) Update(gtx layout.Context) (AlertEvent, bool) {
+	s.update(gtx)
+	if len(s.events) == 0 {
+		return AlertEvent{}, false
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, true
+}
+
+func (s *AlertState) update(gtx layout.Context) {
+	if s.close.Clicked(gtx) && !s.Dismissed {
+		s.Dismissed = true
+		s.events = append(s.events, AlertEvent{Kind: AlertDismissed})
+	}
+	if s.toggle.Clicked(gtx) {
+		s.Expanded = !s.Expanded
+	}
+	for i, btn := range s.actions {
+		if btn.Clicked(gtx) {
+			s.events = append(s.events, AlertEvent{Kind: AlertActionClicked, Action: i})
+		}
+	}
+}
+
+// AlertStyle describes an alert. Without a State it is static: the close
+// button, actions and collapsing need somewhere to keep their state.
+type AlertStyle struct {
+	Title   string
+	Message string
+	Variant AlertVariant
+	// Icon replaces the variant's icon
+	Icon *Icon
+	// Actions label buttons drawn below the message
+	Actions []string
+	// Dismissible adds a close button
+	Dismissible bool
+	// Banner spans the full width with square corners and a bottom border
+	// only, for alerts pinned to the top of a page
+	Banner bool
+	// MaxLines collapses longer messages behind a "Show more" toggle
+	MaxLines int
+	State    *AlertState
+
+	kit *UIKit
+}
+
+// Alert creates a static alert
+func (kit *UIKit) Alert(title, message string, variant AlertVariant) layout.Widget {
+	return kit.AlertStyle(nil, title, message, variant).Layout
+}
+
+// AlertStyle returns an alert that can be further configured before layout
+func (kit *UIKit) AlertStyle(state *AlertState, title, message string, variant AlertVariant) AlertStyle {
+	return AlertStyle{
+		Title:   title,
+		Message: message,
+		Variant: variant,
+		State:   state,
+		kit:     kit,
+	}
+}
+
+// Layout draws the alert. Alerts with a State fade and unfold when they
+// first appear or are shown again, and fold away when dismissed.
+func (a AlertStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := a.State
+	if s == nil {
+		return a.layout(gtx)
+	}
+
+	for len(s.actions) < len(a.Actions) {
+		s.actions = append(s.actions, new(widget.Clickable))
+	}
+	s.actions = s.actions[:len(a.Actions)]
+	s.update(gtx)
+
+	target := float32(1)
+	if s.Dismissed {
+		target = 0
+	}
+	s.visible.Duration = a.kit.Motion.Duration(a.kit.Motion.Medium)
+	s.visible.To(target)
+	v := s.visible.Value(gtx)
+	if v <= 0 {
+		return layout.Dimensions{}
+	}
+	if v >= 1 {
+		return a.layout(gtx)
+	}
+
+	macro := op.Record(gtx.Ops)
+	dims := a.layout(gtx)
+	call := macro.Stop()
+
+	dims.Size.Y = int(float32(dims.Size.Y) * v)
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	defer paint.PushOpacity(gtx.Ops, v).Pop()
+	call.Add(gtx.Ops)
+	return dims
+}
+
+func (a AlertStyle) layout(gtx layout.Context) layout.Dimensions {
+	kit := a.kit
+	s := a.State
+
+	bg, accent, icon := a.colors()
+	if a.Icon != nil {
+		icon = a.Icon
+	}
+	radius := kit.Radius.Medium
+	if a.Banner {
+		radius = 0
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	}
+
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			paint.FillShape(gtx.Ops, bg, clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(radius)).Op(gtx.Ops))
+			if a.Banner {
+				line := image.Rect(0, size.Y-gtx.Dp(1), size.X, size.Y)
+				paint.FillShape(gtx.Ops, accent, clip.Rect(line).Op())
+			} else {
+				kit.strokeRRect(gtx, size, radius, unit.Dp(1), accent)
+			}
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.UniformInset(kit.Spacing.Medium).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Alignment: layout.Start}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layoutIcon(gtx, icon, gtx.Dp(20), accent)
+					}),
+					layout.Rigid(layout.Spacer{Width: kit.Spacing.Medium}.Layout),
+					layout.Flexed(1, a.layoutBody),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if s == nil || !a.Dismissible {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: kit.Spacing.Small}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							btn := kit.ButtonStyle(&s.close, "", ButtonGhost, ButtonSmall)
+							btn.LeadingIcon = kit.Icons.Get(IconClose)
+							btn.Description = "Dismiss"
+							btn.Color = kit.Colors.OnSurface
+							return btn.Layout(gtx)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
+// layoutBody lays out the title, message, expand toggle and actions
+func (a AlertStyle) layoutBody(gtx layout.Context) layout.Dimensions {
+	kit := a.kit
+	s := a.State
+	fg := kit.Colors.OnSurface
+
+	message := kit.Label(a.Message, kit.Typography.BodyMedium, fg)
+	collapsible := false
+	if s != nil && a.MaxLines > 0 {
+		// Measure the full message to see whether it needs collapsing
+		macro := op.Record(gtx.Ops)
+		full := message.Layout(gtx)
+		macro.Stop()
+		collapsible = full.Size.Y > a.MaxLines*gtx.Sp(kit.Typography.BodyMedium.LineHeight)
+		if collapsible && !s.Expanded {
+			message.MaxLines = a.MaxLines
+		}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if a.Title == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Bottom: kit.Spacing.Tiny}.Layout(gtx,
+				kit.Label(a.Title, kit.Typography.LabelMedium, fg).Layout)
+		}),
+		layout.Rigid(message.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !collapsible {
+				return layout.Dimensions{}
+			}
+			text := "Show more"
+			if s.Expanded {
+				text = "Show less"
+			}
+			return layout.Inset{Top: kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				btn := kit.ButtonStyle(&s.toggle, text, ButtonGhost, ButtonSmall)
+				btn.TrailingIcon = kit.Icons.Get(IconExpandMore)
+				if s.Expanded {
+					btn.TrailingIcon = kit.Icons.Get(IconExpandLess)
+				}
+				btn.Color = fg
+				return btn.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if s == nil || len(a.Actions) == 0 {
+				return layout.Dimensions{}
+			}
+			children := make([]layout.FlexChild, 0, 2*len(a.Actions))
+			for i, text := range a.Actions {
+				if i > 0 {
+					children = append(children, layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout))
+				}
+				btn := kit.ButtonStyle(s.actions[i], text, ButtonOutline, ButtonSmall)
+				btn.Color = fg
+				children = append(children, layout.Rigid(btn.Layout))
+			}
+			return layout.Inset{Top: kit.Spacing.Small}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{}.Layout(gtx, children...)
+			})
+		}),
+	)
+}
+
+// colors returns the background, accent and default icon for the variant
+func (a AlertStyle) colors() (bg, accent color.NRGBA, icon *Icon) {
+	c := a.kit.Colors
+	icons := a.kit.Icons
+	switch a.Variant {
+	case AlertSuccess:
+		return c.SuccessLight, c.Success, icons.Get(IconCheckCircle)
+	case AlertWarning:
+		return c.WarningLight, c.Warning, icons.Get(IconWarning)
+	case AlertError:
+		return c.ErrorLight, c.Error, icons.Get(IconError)
+	default:
+		return c.InfoLight, c.Info, icons.Get(IconInfo)
+	}
+}
@@ -0,0 +1,219 @@
+// Package anim animates float values over frame time. Tweens run for a
+// fixed duration along an easing curve; springs follow their target with
+// simple physics. Both read the frame time from gtx.Now and request the next
+// frame themselves while they are moving.
+package anim
+
+import (
+	"math"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// Easing maps linear progress from 0 to 1 onto eased progress
+type Easing func(t float32) float32
+
+// Standard easing curves
+var (
+	Linear    Easing = func(t float32) float32 { return t }
+	EaseIn    Easing = func(t float32) float32 { return t * t * t }
+	EaseOut   Easing = func(t float32) float32 { return 1 - (1-t)*(1-t)*(1-t) }
+	EaseInOut Easing = func(t float32) float32 {
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		u := -2*t + 2
+		return 1 - u*u*u/2
+	}
+	// Standard is the Material 3 curve for most UI transitions
+	Standard = CubicBezier(0.2, 0, 0, 1)
+	// Emphasized starts faster and settles more slowly than Standard
+	Emphasized = CubicBezier(0.05, 0.7, 0.1, 1)
+)
+
+// CubicBezier returns the easing curve of a CSS cubic-bezier(x1, y1, x2, y2)
+func CubicBezier(x1, y1, x2, y2 float32) Easing {
+	bezier := func(a, b, t float32) float32 {
+		u := 1 - t
+		return 3*u*u*t*a + 3*u*t*t*b + t*t*t
+	}
+	return func(x float32) float32 {
+		if x <= 0 || x >= 1 {
+			return x
+		}
+		// Solve bezier(x1, x2, t) = x for t by bisection; x is monotonic in t
+		lo, hi := float32(0), float32(1)
+		for i := 0; i < 20; i++ {
+			mid := (lo + hi) / 2
+			if bezier(x1, x2, mid) < x {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return bezier(y1, y2, (lo+hi)/2)
+	}
+}
+
+// Tween moves a value from where it is to a target over Duration. The zero
+// Tween rests at 0; a zero Duration jumps straight to each new target.
+type Tween struct {
+	Duration time.Duration
+	// Easing defaults to Standard
+	Easing Easing
+
+	from, to float32
+	value    float32
+	start    time.Time
+	running  bool
+}
+
+// Set jumps to v, stopping any animation
+func (t *Tween) Set(v float32) {
+	t.from, t.to, t.value = v, v, v
+	t.running = false
+}
+
+// To starts animating from the current value towards target. The clock
+// starts at the next Value call, so To needs no frame context. Calling To
+// with the target already being animated to does nothing.
+func (t *Tween) To(target float32) {
+	if target == t.to {
+		return
+	}
+	t.from, t.to = t.value, target
+	t.start = time.Time{}
+	t.running = true
+}
+
+// Target returns the value being animated to
+func (t *Tween) Target() float32 {
+	return t.to
+}
+
+// Running reports whether the tween has not yet reached its target
+func (t *Tween) Running() bool {
+	return t.running
+}
+
+// Value returns the value at gtx.Now and requests another frame while the
+// tween is running
+func (t *Tween) Value(gtx layout.Context) float32 {
+	if !t.running {
+		return t.value
+	}
+	if t.start.IsZero() {
+		t.start = gtx.Now
+	}
+	elapsed := gtx.Now.Sub(t.start)
+	if elapsed >= t.Duration {
+		t.value = t.to
+		t.running = false
+		return t.value
+	}
+	ease := t.Easing
+	if ease == nil {
+		ease = Standard
+	}
+	p := ease(float32(elapsed) / float32(t.Duration))
+	t.value = t.from + (t.to-t.from)*p
+	gtx.Execute(op.InvalidateCmd{})
+	return t.value
+}
+
+// Spring follows its target like a damped spring, so retargeting mid-flight
+// keeps the current velocity instead of restarting the motion. The zero
+// Spring rests at 0 with DefaultStiffness and critical damping.
+type Spring struct {
+	// Stiffness pulls the value towards the target; higher is faster
+	Stiffness float32
+	// DampingRatio is 1 for the fastest motion without overshoot; lower
+	// values bounce
+	DampingRatio float32
+
+	value, velocity, target float32
+	last                    time.Time
+	moving                  bool
+}
+
+const (
+	// DefaultStiffness settles in roughly 300ms
+	DefaultStiffness = 400
+	// springStep is the integration step, small enough to stay stable at
+	// high stiffness
+	springStep = time.Second / 240
+	// springRest is how close to the target, in value and velocity per
+	// second, a spring must be to stop
+	springRest = 0.001
+)
+
+// Set jumps to v, stopping any motion
+func (s *Spring) Set(v float32) {
+	s.value, s.target, s.velocity = v, v, 0
+	s.moving = false
+}
+
+// To moves the target; the spring follows from its current state
+func (s *Spring) To(target float32) {
+	if target == s.target {
+		return
+	}
+	s.target = target
+	if !s.moving {
+		s.moving = true
+		s.last = time.Time{}
+	}
+}
+
+// Target returns the value the spring is moving towards
+func (s *Spring) Target() float32 {
+	return s.target
+}
+
+// Running reports whether the spring is still moving
+func (s *Spring) Running() bool {
+	return s.moving
+}
+
+// Value advances the spring to gtx.Now and requests another frame while it
+// is moving
+func (s *Spring) Value(gtx layout.Context) float32 {
+	if !s.moving {
+		return s.value
+	}
+	if s.last.IsZero() {
+		s.last = gtx.Now
+	}
+	k := s.Stiffness
+	if k <= 0 {
+		k = DefaultStiffness
+	}
+	zeta := s.DampingRatio
+	if zeta <= 0 {
+		zeta = 1
+	}
+	c := 2 * zeta * float32(math.Sqrt(float64(k)))
+
+	// Cap the catch-up after a stall so a long pause doesn't spin the loop
+	elapsed := min(gtx.Now.Sub(s.last), 100*time.Millisecond)
+	s.last = gtx.Now
+	dt := float32(springStep.Seconds())
+	for ; elapsed > 0; elapsed -= springStep {
+		accel := -k*(s.value-s.target) - c*s.velocity
+		s.velocity += accel * dt
+		s.value += s.velocity * dt
+	}
+
+	if abs(s.value-s.target) < springRest && abs(s.velocity) < springRest {
+		s.Set(s.target)
+		return s.value
+	}
+	gtx.Execute(op.InvalidateCmd{})
+	return s.value
+}
+
+func abs(v float32) float32 {
+	return float32(math.Abs(float64(v)))
+}
@@ -0,0 +1,63 @@
+package anim
+
+import (
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+)
+
+func TestCubicBezierEndpoints(t *testing.T) {
+	ease := CubicBezier(0.2, 0, 0, 1)
+	if got := ease(0); got != 0 {
+		t.Errorf("ease(0) = %v, want 0", got)
+	}
+	if got := ease(1); got != 1 {
+		t.Errorf("ease(1) = %v, want 1", got)
+	}
+	if mid := ease(0.5); mid <= 0.5 || mid >= 1 {
+		t.Errorf("ease(0.5) = %v, want an eased-out value in (0.5, 1)", mid)
+	}
+}
+
+func TestTween(t *testing.T) {
+	start := time.Unix(0, 0)
+	gtx := layout.Context{Now: start}
+	tw := Tween{Duration: 100 * time.Millisecond, Easing: Linear}
+	tw.To(1)
+
+	if got := tw.Value(gtx); got != 0 {
+		t.Errorf("value at start = %v, want 0", got)
+	}
+	gtx.Now = start.Add(50 * time.Millisecond)
+	if got := tw.Value(gtx); got != 0.5 {
+		t.Errorf("value halfway = %v, want 0.5", got)
+	}
+	gtx.Now = start.Add(time.Second)
+	if got := tw.Value(gtx); got != 1 || tw.Running() {
+		t.Errorf("value after duration = %v, running %v; want 1, false", got, tw.Running())
+	}
+
+	// A zero duration jumps straight to the target
+	tw.Duration = 0
+	tw.To(0.25)
+	if got := tw.Value(gtx); got != 0.25 {
+		t.Errorf("value with zero duration = %v, want 0.25", got)
+	}
+}
+
+func TestSpringSettles(t *testing.T) {
+	gtx := layout.Context{Now: time.Unix(0, 0)}
+	var s Spring
+	s.To(1)
+	for i := 0; i < 120 && s.Running(); i++ {
+		v := s.Value(gtx)
+		if v > 1+springRest {
+			t.Fatalf("critically damped spring overshot to %v", v)
+		}
+		gtx.Now = gtx.Now.Add(time.Second / 60)
+	}
+	if s.Running() || s.Value(gtx) != 1 {
+		t.Errorf("spring still moving at %v after 2s", s.Value(gtx))
+	}
+}
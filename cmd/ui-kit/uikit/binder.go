@@ -0,0 +1,597 @@
+package uikit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"uikit/uikit/form"
+)
+
+// Struct binding
+//
+// A Binder lays out a form for the exported fields of a struct, configured
+// by `ui` tags holding a comma-separated list of keys:
+//
+//	label=Email      label shown above the field; defaults to the field name
+//	hint=...         placeholder text
+//	helper=...       text shown under the field while there is no error
+//	icon=mail        registered icon drawn before the text
+//	validate=a|b     validators: required, email, number, integer, minlen:N
+//	                 and maxlen:N; numeric fields check their format anyway
+//	options=A|B|C    choose a string from a fixed set
+//	min=0,max=100    edit a number with a slider
+//	multiline        text spanning several lines
+//
+// A tag of "-" skips the field. Values cannot contain commas. Strings and
+// numbers are edited in Inputs and bools with checkboxes. A few options are
+// radio buttons; longer lists are chosen from a Select. Validators not
+// expressible in a tag can be added through Form.Lookup with the Go field
+// name.
+
+// bindKind is how a struct field is edited
+type bindKind int
+
+const (
+	bindText bindKind = iota
+	bindNumber
+	bindBool
+	bindSlider
+	bindOptions
+)
+
+// Height bounds of a multiline field, in lines
+const (
+	multilineMinRows = 4
+	multilineMaxRows = 10
+)
+
+// maxRadioOptions is the most options shown as radio buttons
+const maxRadioOptions = 4
+
+// Binder keeps a struct and the form generated for it in sync: edits are
+// written to the struct as they happen, and changes the program makes to
+// the struct show in the form on the next frame.
+type Binder struct {
+	// Form validates the bound fields; set its Mode and Invalidate as needed
+	Form form.Form
+
+	kit     *UIKit
+	target  reflect.Value
+	initial reflect.Value
+	fields  []*boundField
+}
+
+type boundField struct {
+	name      string
+	label     string
+	hint      string
+	helper    string
+	icon      *Icon
+	kind      bindKind
+	multiline bool
+	maxLen    int // From a maxlen validator, for the counter
+	options   []string
+	min, max  float64
+
+	value  reflect.Value // The struct field
+	synced any           // Struct value the widget last showed
+	text   string        // Editor text last seen
+
+	editor TextArea // Also holds single line text
+	check  widget.Bool
+	slider Slider
+	choice widget.Enum
+	menu   Select
+	field  *form.Field
+}
+
+// Bind builds a form for the struct ptr points to. The values the struct
+// holds now are the ones Reset returns to.
+func (kit *UIKit) Bind(ptr any) (*Binder, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bind %T: not a pointer to a struct", ptr)
+	}
+	b := &Binder{kit: kit, target: v.Elem()}
+	b.initial = reflect.New(b.target.Type()).Elem()
+	b.initial.Set(b.target)
+
+	t := b.target.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("ui")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		f, err := b.bindField(sf, b.target.Field(i), tag)
+		if err != nil {
+			return nil, fmt.Errorf("bind field %s: %w", sf.Name, err)
+		}
+		b.fields = append(b.fields, f)
+	}
+	return b, nil
+}
+
+func (b *Binder) bindField(sf reflect.StructField, v reflect.Value, tag string) (*boundField, error) {
+	f := &boundField{name: sf.Name, label: sf.Name, value: v}
+	var validate []string
+	var hasMin, hasMax bool
+	for _, item := range strings.Split(tag, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(item), "=")
+		var err error
+		switch key {
+		case "":
+		case "label":
+			f.label = val
+		case "hint":
+			f.hint = val
+		case "helper":
+			f.helper = val
+		case "icon":
+			if f.icon = b.kit.Icons.Get(val); f.icon == nil {
+				return nil, fmt.Errorf("unknown icon %q", val)
+			}
+		case "validate":
+			validate = strings.Split(val, "|")
+		case "options":
+			f.options = strings.Split(val, "|")
+		case "min":
+			f.min, err = strconv.ParseFloat(val, 64)
+			hasMin = true
+		case "max":
+			f.max, err = strconv.ParseFloat(val, 64)
+			hasMax = true
+		case "multiline":
+			f.multiline = true
+		default:
+			return nil, fmt.Errorf("unknown tag key %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	switch k := v.Kind(); {
+	case k == reflect.String && f.options != nil:
+		f.kind = bindOptions
+	case k == reflect.String:
+		f.kind = bindText
+	case k == reflect.Bool:
+		f.kind = bindBool
+	case isNumber(k) && hasMin && hasMax:
+		if f.max <= f.min {
+			return nil, errors.New("max must be greater than min")
+		}
+		f.kind = bindSlider
+	case isNumber(k):
+		f.kind = bindNumber
+		if k == reflect.Float32 || k == reflect.Float64 {
+			validate = append(validate, "number")
+		} else {
+			validate = append(validate, "integer")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	validators, err := parseValidators(validate, f.kind, v.Type())
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range validate {
+		if arg, ok := strings.CutPrefix(name, "maxlen:"); ok && f.kind == bindText {
+			f.maxLen, _ = strconv.Atoi(arg)
+		}
+	}
+	switch f.kind {
+	case bindText, bindNumber:
+		f.editor.SingleLine = !f.multiline
+		f.field = b.Form.Editor(f.name, &f.editor.Editor, validators...)
+	default:
+		f.field = b.Form.Field(f.name, f.format, nil, validators...)
+	}
+	f.pull()
+	return f, nil
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// integerPattern matches what strconv.ParseInt accepts, give or take spaces
+var integerPattern = regexp.MustCompile(`^\s*[-+]?\d+\s*$`)
+
+// parseValidators turns the names of a validate tag into validators for a
+// field of type t. Numbers are checked against what t can hold; fields of
+// other types take any float64 or int64.
+func parseValidators(names []string, kind bindKind, t reflect.Type) ([]form.Validator, error) {
+	var vs []form.Validator
+	for _, name := range names {
+		name, arg, _ := strings.Cut(name, ":")
+		switch name {
+		case "":
+		case "required":
+			if kind == bindBool {
+				// A required checkbox must be checked
+				vs = append(vs, form.Func(func(v string) bool { return v == "true" }, "This field is required"))
+			} else {
+				vs = append(vs, form.Required())
+			}
+		case "email":
+			vs = append(vs, form.Email())
+		case "number":
+			if k := t.Kind(); k != reflect.Float32 && k != reflect.Float64 {
+				t = reflect.TypeFor[float64]()
+			}
+			vs = append(vs, numberValidator(t))
+		case "integer":
+			if k := t.Kind(); !isNumber(k) || k == reflect.Float32 || k == reflect.Float64 {
+				t = reflect.TypeFor[int64]()
+			}
+			vs = append(vs, numberValidator(t))
+		case "minlen", "maxlen":
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, fmt.Errorf("validator %s: %w", name, err)
+			}
+			if name == "minlen" {
+				vs = append(vs, form.MinLength(n))
+			} else {
+				vs = append(vs, form.MaxLength(n))
+			}
+		default:
+			return nil, fmt.Errorf("unknown validator %q", name)
+		}
+	}
+	return vs, nil
+}
+
+// numberValidator accepts numbers a value of type t can hold, whole numbers
+// only for integer types
+func numberValidator(t reflect.Type) form.Validator {
+	return func(v string) error {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil
+		}
+		var err error
+		switch t.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if _, err = strconv.ParseFloat(v, t.Bits()); errors.Is(err, strconv.ErrRange) {
+				return errors.New("Out of range; enter a smaller number")
+			} else if err != nil {
+				return errors.New("Enter a number")
+			}
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			_, err = strconv.ParseUint(strings.TrimPrefix(v, "+"), 10, t.Bits())
+			if err != nil && integerPattern.MatchString(v) {
+				err = strconv.ErrRange // A negative number
+			}
+		default:
+			_, err = strconv.ParseInt(v, 10, t.Bits())
+		}
+		switch {
+		case errors.Is(err, strconv.ErrRange):
+			low, high := intRange(t)
+			return fmt.Errorf("Out of range; enter a whole number from %s to %s", low, high)
+		case err != nil || !integerPattern.MatchString(v):
+			return errors.New("Enter a whole number")
+		}
+		return nil
+	}
+}
+
+// intRange returns the smallest and largest values of an integer type
+func intRange(t reflect.Type) (low, high string) {
+	bits := t.Bits()
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "0", strconv.FormatUint(math.MaxUint64>>(64-bits), 10)
+	}
+	return strconv.FormatInt(math.MinInt64>>(64-bits), 10), strconv.FormatInt(math.MaxInt64>>(64-bits), 10)
+}
+
+// Update copies edits into the struct and struct changes into the form,
+// then updates the Form. It reports whether the user changed a value.
+func (b *Binder) Update(gtx layout.Context) bool {
+	changed := false
+	for _, f := range b.fields {
+		if f.push(gtx) {
+			changed = true
+		}
+		if f.value.Interface() != f.synced {
+			f.pull()
+		}
+	}
+	b.Form.Update(gtx)
+	return changed
+}
+
+// Submit shows every validation error and reports whether the struct holds
+// a valid submission
+func (b *Binder) Submit(gtx layout.Context) bool {
+	b.Update(gtx)
+	return b.Form.Submit(gtx)
+}
+
+// Submitted reports whether Ctrl+Enter, or Cmd+Enter on macOS, was pressed
+// in a multiline field since the last call
+func (b *Binder) Submitted(gtx layout.Context) bool {
+	submitted := false
+	for _, f := range b.fields {
+		if f.multiline && f.editor.Submitted(gtx) {
+			submitted = true
+		}
+	}
+	return submitted
+}
+
+// Reset restores the values the struct held when it was bound and hides
+// validation errors
+func (b *Binder) Reset() {
+	b.target.Set(b.initial)
+	for _, f := range b.fields {
+		f.pull()
+	}
+	b.Form.Reset()
+}
+
+// Layout lays out every bound field, one below the other
+func (b *Binder) Layout(gtx layout.Context) layout.Dimensions {
+	b.Update(gtx)
+	children := make([]layout.FlexChild, 0, 2*len(b.fields))
+	for i, f := range b.fields {
+		if i > 0 {
+			children = append(children, layout.Rigid(layout.Spacer{Height: b.kit.Spacing.Medium}.Layout))
+		}
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return b.layoutField(gtx, f)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// Widget returns the labeled field bound to the named struct field, for
+// forms that arrange their fields themselves, or nil if there is none
+func (b *Binder) Widget(name string) layout.Widget {
+	for _, f := range b.fields {
+		if f.name == name {
+			return func(gtx layout.Context) layout.Dimensions {
+				return b.layoutField(gtx, f)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Binder) layoutField(gtx layout.Context, f *boundField) layout.Dimensions {
+	kit := b.kit
+	switch f.kind {
+	case bindBool:
+		c := kit.CheckboxStyle(&f.check, f.label)
+		c.Helper = f.helper
+		c.Error = f.field.Error()
+		return c.Layout(gtx)
+	case bindOptions:
+		if len(f.options) > maxRadioOptions {
+			sel := kit.SelectStyle(&f.menu, f.label, SelectOptions(f.options...)...)
+			sel.Hint = f.hint
+			sel.Helper = f.helper
+			sel.Error = f.field.Error()
+			return sel.Layout(gtx)
+		}
+		r := kit.RadioGroupStyle(&f.choice, RadioOptions(f.options...)...)
+		r.Label = f.label
+		r.Axis = layout.Horizontal
+		r.Helper = f.helper
+		r.Error = f.field.Error()
+		return r.Layout(gtx)
+	}
+
+	if f.kind == bindText || f.kind == bindNumber {
+		in := kit.InputStyle(&f.editor.Editor, f.hint)
+		in.Label = f.label
+		in.PrefixIcon = f.icon
+		in.Helper = f.helper
+		if f.field.Validating() {
+			in.Helper = "Checking..."
+		}
+		in.Error = f.field.Error()
+		in.MaxLength = f.maxLen
+		if f.multiline {
+			area := TextAreaStyle{InputStyle: in, State: &f.editor, MinRows: multilineMinRows, MaxRows: multilineMaxRows, Resizable: true}
+			return area.Layout(gtx)
+		}
+		return in.Layout(gtx)
+	}
+
+	label := fmt.Sprintf("%s: %s", f.label, f.format())
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(kit.Label(label, kit.Typography.LabelMedium, kit.Colors.TextPrimary).Layout),
+		layout.Rigid(layout.Spacer{Height: kit.Spacing.Tiny}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			sl := kit.SliderStyle(&f.slider, float32(f.min), float32(f.max))
+			if k := f.value.Kind(); k != reflect.Float32 && k != reflect.Float64 {
+				sl.Step = 1 // Integers slide in whole steps
+			}
+			return b.layoutError(gtx, f, sl.Layout)
+		}),
+	)
+}
+
+// layoutError lays out w with the field's error, if any, below it. Inputs
+// and selection controls draw their own.
+func (b *Binder) layoutError(gtx layout.Context, f *boundField, w layout.Widget) layout.Dimensions {
+	msg := f.field.Error()
+	if msg == "" {
+		return w(gtx)
+	}
+	kit := b.kit
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(w),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: kit.Spacing.Tiny}.Layout(gtx,
+				kit.Label(msg, kit.Typography.LabelSmall, kit.Colors.Error).Layout)
+		}),
+	)
+}
+
+// push writes a user edit of the widget into the struct and reports
+// whether there was one
+func (f *boundField) push(gtx layout.Context) bool {
+	switch f.kind {
+	case bindText:
+		text := f.editor.Text()
+		if text == f.text {
+			return false
+		}
+		f.text = text
+		f.value.SetString(text)
+	case bindNumber:
+		text := f.editor.Text()
+		if text == f.text {
+			return false
+		}
+		f.text = text
+		// Leave the struct alone until the text parses; the form reports the error
+		if !f.setNumber(strings.TrimSpace(text)) {
+			return true
+		}
+	case bindBool:
+		if !f.check.Update(gtx) {
+			return false
+		}
+		f.value.SetBool(f.check.Value)
+	case bindSlider:
+		if !f.slider.Update(gtx) {
+			return false
+		}
+		f.setFloat(float64(f.slider.Value))
+	case bindOptions:
+		if len(f.options) > maxRadioOptions {
+			if !f.menu.Update(gtx) {
+				return false
+			}
+			f.value.SetString(f.menu.Value)
+			break
+		}
+		if !f.choice.Update(gtx) {
+			return false
+		}
+		f.value.SetString(f.choice.Value)
+	}
+	f.synced = f.value.Interface()
+	return true
+}
+
+// pull shows the struct value in the widget
+func (f *boundField) pull() {
+	f.synced = f.value.Interface()
+	switch f.kind {
+	case bindText, bindNumber:
+		text := f.format()
+		if f.kind == bindNumber && f.showsNumber() {
+			// The editor already shows this number, perhaps spelled differently
+			return
+		}
+		f.text = text
+		f.editor.SetText(text)
+	case bindBool:
+		f.check.Value = f.value.Bool()
+	case bindSlider:
+		f.slider.Value = float32(f.float())
+	case bindOptions:
+		f.choice.Value = f.value.String()
+		f.menu.Value = f.value.String()
+	}
+}
+
+// format returns the struct value as text
+func (f *boundField) format() string {
+	v := f.value
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Float32, reflect.Float64:
+		if f.kind == bindSlider {
+			return strconv.FormatFloat(v.Float(), 'f', 1, 64)
+		}
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	default:
+		return strconv.FormatInt(v.Int(), 10)
+	}
+}
+
+func (f *boundField) float() float64 {
+	switch f.value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return f.value.Float()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(f.value.Uint())
+	default:
+		return float64(f.value.Int())
+	}
+}
+
+// setFloat stores n, rounding it for integer fields
+func (f *boundField) setFloat(n float64) {
+	switch f.value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f.value.SetFloat(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f.value.SetUint(uint64(math.Round(max(0, n))))
+	default:
+		f.value.SetInt(int64(math.Round(n)))
+	}
+}
+
+// setNumber parses text into the struct field and reports whether it could
+func (f *boundField) setNumber(text string) bool {
+	switch f.value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, f.value.Type().Bits())
+		if err != nil {
+			return false
+		}
+		f.value.SetFloat(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimPrefix(text, "+"), 10, f.value.Type().Bits())
+		if err != nil {
+			return false
+		}
+		f.value.SetUint(n)
+	default:
+		n, err := strconv.ParseInt(text, 10, f.value.Type().Bits())
+		if err != nil {
+			return false
+		}
+		f.value.SetInt(n)
+	}
+	return true
+}
+
+// showsNumber reports whether the editor text parses to the number the
+// struct holds
+func (f *boundField) showsNumber() bool {
+	n, err := strconv.ParseFloat(strings.TrimSpace(f.text), 64)
+	return err == nil && n == f.float()
+}
@@ -0,0 +1,203 @@
+package uikit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gioui.org/layout"
+)
+
+type bindProfile struct {
+	Name   string `ui:"label=Name,validate=required|maxlen:20"`
+	Age    int
+	Visits uint16
+	Ratio  float32
+	Level  int `ui:"min=0,max=10"`
+	Agree  bool
+	Plan   string `ui:"options=Free|Team"`
+	Notes  string `ui:"-"`
+}
+
+// boundNamed returns the field bound to the Go field name
+func boundNamed(t *testing.T, b *Binder, name string) *boundField {
+	t.Helper()
+	for _, f := range b.fields {
+		if f.name == name {
+			return f
+		}
+	}
+	t.Fatalf("no bound field %s", name)
+	return nil
+}
+
+func TestBindErrors(t *testing.T) {
+	kit := NewUIKit()
+	tests := []struct {
+		target any
+		want   string
+	}{
+		{bindProfile{}, "not a pointer to a struct"},
+		{&struct {
+			A string `ui:"colour=red"`
+		}{}, `unknown tag key "colour"`},
+		{&struct {
+			A string `ui:"icon=nope"`
+		}{}, `unknown icon "nope"`},
+		{&struct {
+			A string `ui:"validate=required|phone"`
+		}{}, `unknown validator "phone"`},
+		{&struct {
+			A string `ui:"validate=minlen:x"`
+		}{}, "validator minlen"},
+		{&struct {
+			A int `ui:"min=5,max=5"`
+		}{}, "max must be greater than min"},
+		{&struct {
+			A float64 `ui:"min=1,max=0"`
+		}{}, "max must be greater than min"},
+		{&struct {
+			A int `ui:"min=low,max=5"`
+		}{}, "min:"},
+		{&struct{ A []string }{}, "unsupported type"},
+	}
+	for _, tt := range tests {
+		_, err := kit.Bind(tt.target)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Bind(%T) = %v; want an error containing %q", tt.target, err, tt.want)
+		}
+	}
+}
+
+func TestBindNumbers(t *testing.T) {
+	b, err := NewUIKit().Bind(&bindProfile{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		field, text string
+		want        string // Formatted value; empty if the text is rejected
+	}{
+		{"Age", "-42", "-42"},
+		{"Age", "1.5", ""},
+		{"Visits", "65535", "65535"},
+		{"Visits", "65536", ""},
+		{"Visits", "-1", ""},
+		{"Ratio", "0.1", "0.1"},
+		{"Ratio", "-2.5e-3", "-0.0025"},
+		{"Ratio", "abc", ""},
+	}
+	for _, tt := range tests {
+		f := boundNamed(t, b, tt.field)
+		want := tt.want
+		if want == "" {
+			want = f.format()
+		}
+		ok := f.setNumber(tt.text)
+		if ok != (tt.want != "") || f.format() != want {
+			t.Errorf("%s: setNumber(%q) = %v, formats as %q; want %v, %q", tt.field, tt.text, ok, f.format(), tt.want != "", want)
+		}
+	}
+}
+
+func TestNumberValidator(t *testing.T) {
+	tests := []struct {
+		t    reflect.Type
+		text string
+		want string // Error message, or empty
+	}{
+		{reflect.TypeFor[uint8](), "255", ""},
+		{reflect.TypeFor[uint8](), "+7", ""},
+		{reflect.TypeFor[uint8](), "300", "Out of range; enter a whole number from 0 to 255"},
+		{reflect.TypeFor[uint](), "-1", "Out of range; enter a whole number from 0 to 18446744073709551615"},
+		{reflect.TypeFor[int8](), "-128", ""},
+		{reflect.TypeFor[int8](), "128", "Out of range; enter a whole number from -128 to 127"},
+		{reflect.TypeFor[int](), "123456789012345678901234567890", "Out of range; enter a whole number from -9223372036854775808 to 9223372036854775807"},
+		{reflect.TypeFor[int](), "1.5", "Enter a whole number"},
+		{reflect.TypeFor[float32](), "1e38", ""},
+		{reflect.TypeFor[float32](), "1e39", "Out of range; enter a smaller number"},
+		{reflect.TypeFor[float64](), "1e39", ""},
+		{reflect.TypeFor[float64](), "x", "Enter a number"},
+		{reflect.TypeFor[int](), "", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if err := numberValidator(tt.t)(tt.text); err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("%s %q: got error %q; want %q", tt.t, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBindUpdate(t *testing.T) {
+	var gtx layout.Context
+	p := &bindProfile{Name: "Jane", Age: 30, Plan: "Free"}
+	b, err := NewUIKit().Bind(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(b.fields); got != 7 {
+		t.Errorf("bound %d fields; want 7 without the skipped one", got)
+	}
+	name, age := boundNamed(t, b, "Name"), boundNamed(t, b, "Age")
+	if b.Update(gtx) {
+		t.Error("Update reported a change before any edit")
+	}
+
+	// Changes made by the program show in the form
+	p.Name, p.Age, p.Level, p.Agree, p.Plan = "Ada", 36, 7, true, "Team"
+	b.Update(gtx)
+	if name.editor.Text() != "Ada" || age.editor.Text() != "36" {
+		t.Errorf("editors show %q and %q; want Ada and 36", name.editor.Text(), age.editor.Text())
+	}
+	if boundNamed(t, b, "Level").slider.Value != 7 || !boundNamed(t, b, "Agree").check.Value || boundNamed(t, b, "Plan").choice.Value != "Team" {
+		t.Error("slider, checkbox or options do not show the struct")
+	}
+
+	// Edits are written to the struct, unless they do not parse
+	name.editor.SetText("Grace")
+	age.editor.SetText("4x")
+	if !b.Update(gtx) {
+		t.Error("Update reported no change after an edit")
+	}
+	if p.Name != "Grace" || p.Age != 36 {
+		t.Errorf("struct holds %q, %d; want Grace, 36", p.Name, p.Age)
+	}
+	if age.editor.Text() != "4x" {
+		t.Errorf("unparsed edit replaced by %q", age.editor.Text())
+	}
+}
+
+func TestBindReset(t *testing.T) {
+	var gtx layout.Context
+	p := &bindProfile{Name: "Jane", Age: 30}
+	b, err := NewUIKit().Bind(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Age = 31
+	boundNamed(t, b, "Name").editor.SetText("")
+	b.Update(gtx)
+	if b.Submit(gtx) {
+		t.Error("submitted without the required name")
+	}
+	boundNamed(t, b, "Name").editor.SetText("Jane")
+	boundNamed(t, b, "Visits").editor.SetText("70000")
+	b.Update(gtx)
+	if b.Submit(gtx) {
+		t.Error("submitted with visits out of range")
+	}
+
+	b.Reset()
+	if p.Name != "Jane" || p.Age != 30 {
+		t.Errorf("after Reset the struct holds %q, %d; want Jane, 30", p.Name, p.Age)
+	}
+	if got := boundNamed(t, b, "Name").editor.Text(); got != "Jane" {
+		t.Errorf("after Reset the name shows %q", got)
+	}
+	if err := boundNamed(t, b, "Name").field.Error(); err != "" {
+		t.Errorf("after Reset the name shows the error %q", err)
+	}
+}
@@ -0,0 +1,291 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// Button Variants
+type ButtonVariant int
+
+const (
+	ButtonPrimary ButtonVariant = iota
+	ButtonSecondary
+	ButtonOutline
+	ButtonGhost
+	ButtonDanger
+	ButtonSuccess
+)
+
+type ButtonSize int
+
+const (
+	ButtonSmall ButtonSize = iota
+	ButtonMedium
+	ButtonLarge
+)
+
+// State layer opacities drawn over a button in the foreground color
+const (
+	hoverLayerAlpha   = 0x14 // 8%
+	focusLayerAlpha   = 0x1F // 12%
+	pressedLayerAlpha = 0x29 // 16%
+)
+
+// ButtonStyle describes a kit button and its interaction state. The
+// widget.Clickable holds the persistent part; everything else is set per frame.
+type ButtonStyle struct {
+	Text    string
+	Variant ButtonVariant
+	Size    ButtonSize
+	// Disabled dims the button and stops it from receiving clicks or focus
+	Disabled bool
+	// Loading shows a spinner in place of the leading icon, or before the
+	// label, and suppresses clicks while keeping the variant's colors
+	Loading bool
+	// Icons drawn either side of the label; with no Text the button is
+	// icon-only
+	LeadingIcon  *Icon
+	TrailingIcon *Icon
+	// Description is announced by screen readers, for icon-only buttons
+	Description string
+	// Color overrides the variant's content and border color, for ghost and
+	// outline buttons drawn on tinted surfaces
+	Color  color.NRGBA
+	Button *widget.Clickable
+
+	kit *UIKit
+}
+
+// Button creates a styled button with consistent design
+func (kit *UIKit) Button(btn *widget.Clickable, text string, variant ButtonVariant, size ButtonSize) layout.Widget {
+	return kit.ButtonStyle(btn, text, variant, size).Layout
+}
+
+// ButtonStyle returns a button that can be further configured before layout
+func (kit *UIKit) ButtonStyle(btn *widget.Clickable, text string, variant ButtonVariant, size ButtonSize) ButtonStyle {
+	return ButtonStyle{
+		Text:    text,
+		Variant: variant,
+		Size:    size,
+		Button:  btn,
+		kit:     kit,
+	}
+}
+
+// IconButton creates an icon-only button; description labels it for screen readers
+func (kit *UIKit) IconButton(btn *widget.Clickable, icon *Icon, description string, variant ButtonVariant, size ButtonSize) layout.Widget {
+	b := kit.ButtonStyle(btn, "", variant, size)
+	b.LeadingIcon = icon
+	b.Description = description
+	return b.Layout
+}
+
+// Layout draws the button. Disabled and loading buttons are laid out
+// without their Clickable, so they neither receive pointer input nor take
+// keyboard focus. Loading buttons stay enabled so their spinner can ask for
+// the next frame.
+func (b ButtonStyle) Layout(gtx layout.Context) layout.Dimensions {
+	switch {
+	case b.Disabled:
+		return b.layout(gtx.Disabled())
+	case b.Loading:
+		return b.layout(gtx)
+	}
+	return b.Button.Layout(gtx, b.layout)
+}
+
+func (b ButtonStyle) layout(gtx layout.Context) layout.Dimensions {
+	kit := b.kit
+	semantic.Button.Add(gtx.Ops)
+	if b.Description != "" {
+		semantic.DescriptionOp(b.Description).Add(gtx.Ops)
+	}
+
+	inset, typo := b.metrics()
+	bg, fg, border := b.colors()
+	interactive := gtx.Enabled() && !b.Loading
+	hovered := interactive && b.Button.Hovered()
+	pressed := interactive && b.Button.Pressed()
+	focused := interactive && gtx.Focused(b.Button)
+
+	// Primary and secondary have designed hover and pressed shades; the
+	// other variants get a translucent state layer in their content color.
+	// Both ease between rest (0), hover or focus (1) and pressed (2).
+	var level float32
+	switch {
+	case b.Disabled:
+	case pressed:
+		level = 2
+	case hovered, focused:
+		level = 1
+	}
+	tw := kit.tween(gtx, b.Button)
+	tw.Duration = kit.Motion.Duration(kit.Motion.Fast)
+	tw.To(level)
+	level = tw.Value(gtx)
+
+	var layer uint8
+	switch {
+	case b.Disabled:
+	case b.Variant == ButtonPrimary:
+		bg = mixStops(level, bg, kit.Colors.Primary600, kit.Colors.Primary700)
+	case b.Variant == ButtonSecondary:
+		bg = mixStops(level, bg, kit.Colors.Gray200, kit.Colors.Gray300)
+	default:
+		hover := float32(hoverLayerAlpha)
+		if focused {
+			hover = focusLayerAlpha
+		}
+		if level <= 1 {
+			layer = uint8(hover * level)
+		} else {
+			layer = uint8(hover + (pressedLayerAlpha-hover)*(level-1))
+		}
+	}
+
+	return layout.Stack{Alignment: layout.Center}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			rr := gtx.Dp(kit.Radius.Medium)
+			area := clip.UniformRRect(image.Rectangle{Max: size}, rr)
+
+			paint.FillShape(gtx.Ops, bg, area.Op(gtx.Ops))
+			if layer > 0 {
+				paint.FillShape(gtx.Ops, withAlpha(fg, layer), area.Op(gtx.Ops))
+			}
+			if border.A > 0 {
+				kit.strokeRRect(gtx, size, kit.Radius.Medium, unit.Dp(1), border)
+			}
+			if focused {
+				kit.strokeRRect(gtx, size, kit.Radius.Medium, unit.Dp(2), kit.Colors.Focus)
+			}
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.UniformInset(inset).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return b.layoutContent(gtx, typo, fg)
+			})
+		}),
+	)
+}
+
+// layoutContent lays out the leading slot, label and trailing icon in a row
+func (b ButtonStyle) layoutContent(gtx layout.Context, typo TypographyStyle, fg color.NRGBA) layout.Dimensions {
+	kit := b.kit
+	iconSize := gtx.Sp(typo.LineHeight)
+	gap := layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout)
+
+	var children []layout.FlexChild
+	switch {
+	case b.Loading:
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints = layout.Exact(image.Pt(iconSize, iconSize))
+			return material.LoaderStyle{Color: fg}.Layout(gtx)
+		}))
+	case b.LeadingIcon != nil:
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layoutIcon(gtx, b.LeadingIcon, iconSize, fg)
+		}))
+	}
+	if b.Text != "" {
+		if len(children) > 0 {
+			children = append(children, gap)
+		}
+		children = append(children, layout.Rigid(kit.Label(b.Text, typo, fg).Layout))
+	}
+	if b.TrailingIcon != nil {
+		if len(children) > 0 {
+			children = append(children, gap)
+		}
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layoutIcon(gtx, b.TrailingIcon, iconSize, fg)
+		}))
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
+
+// metrics returns the inset and label style for the button size
+func (b ButtonStyle) metrics() (unit.Dp, TypographyStyle) {
+	kit := b.kit
+	switch b.Size {
+	case ButtonSmall:
+		return kit.Spacing.Small, kit.Typography.LabelSmall
+	case ButtonLarge:
+		return kit.Spacing.Large, kit.Typography.LabelLarge
+	default:
+		return kit.Spacing.Medium, kit.Typography.LabelMedium
+	}
+}
+
+// colors returns the resting background, content and border colors
+func (b ButtonStyle) colors() (bg, fg, border color.NRGBA) {
+	bg, fg, border = b.variantColors()
+	if b.Color.A > 0 && !b.Disabled {
+		fg = b.Color
+		if border.A > 0 {
+			border = b.Color
+		}
+	}
+	return bg, fg, border
+}
+
+func (b ButtonStyle) variantColors() (bg, fg, border color.NRGBA) {
+	c := b.kit.Colors
+	if b.Disabled {
+		switch b.Variant {
+		case ButtonOutline:
+			return color.NRGBA{}, c.TextDisabled, c.Border
+		case ButtonGhost:
+			return color.NRGBA{}, c.TextDisabled, color.NRGBA{}
+		default:
+			return c.Gray100, c.TextDisabled, color.NRGBA{}
+		}
+	}
+
+	switch b.Variant {
+	case ButtonSecondary:
+		return c.Gray100, c.OnSecondary, color.NRGBA{}
+	case ButtonOutline:
+		return color.NRGBA{}, c.Primary500, c.Primary500
+	case ButtonGhost:
+		return color.NRGBA{}, c.Primary500, color.NRGBA{}
+	case ButtonDanger:
+		return c.Error, c.OnError, color.NRGBA{}
+	case ButtonSuccess:
+		return c.Success, c.OnSuccess, color.NRGBA{}
+	default:
+		return c.Primary500, c.OnPrimary, color.NRGBA{}
+	}
+}
+
+// mixStops interpolates rest, hover and pressed colors for a level from 0 to 2
+func mixStops(level float32, rest, hover, pressed color.NRGBA) color.NRGBA {
+	switch {
+	case level <= 0:
+		return rest
+	case level <= 1:
+		return mix(rest, hover, float64(level))
+	default:
+		return mix(hover, pressed, float64(level-1))
+	}
+}
+
+// strokeRRect outlines a rounded rectangle of the given size, inside its bounds
+func (kit *UIKit) strokeRRect(gtx layout.Context, size image.Point, radius, width unit.Dp, col color.NRGBA) {
+	widget.Border{
+		Color:        col,
+		CornerRadius: radius,
+		Width:        width,
+	}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: size}
+	})
+}
@@ -0,0 +1,341 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// Card looks
+type CardVariant int
+
+const (
+	// CardElevated floats above the page on a shadow
+	CardElevated CardVariant = iota
+	// CardOutlined is flat with a border
+	CardOutlined
+	// CardFilled is flat on a tinted background
+	CardFilled
+)
+
+// cardAvatarSize is the size of a header avatar
+const cardAvatarSize = unit.Dp(40)
+
+// CardStyle describes a card. Every slot is optional; they are laid out
+// top to bottom as media, header, content and footer.
+type CardStyle struct {
+	Variant CardVariant
+	// Elevation of a CardElevated card
+	Elevation Elevation
+
+	// Header
+	Title    string
+	Subtitle string
+	// Avatar is drawn before the title, e.g. an icon or a round image
+	Avatar layout.Widget
+	// Action is drawn at the end of the header, e.g. an icon button
+	Action layout.Widget
+
+	// Media is drawn edge to edge at the top, e.g. a widget.Image
+	Media   layout.Widget
+	Content layout.Widget
+	// Footer widgets are laid out in a right-aligned row, usually buttons
+	Footer []layout.Widget
+
+	// Clickable makes the whole card a button, with hover and press states.
+	// Buttons in the header and footer keep their own clicks.
+	Clickable *widget.Clickable
+	// Description is announced by screen readers for clickable cards
+	Description string
+	// FullWidth stretches the card across the available width; otherwise it
+	// is as wide as its content
+	FullWidth bool
+	// Padding around everything but the media
+	Padding unit.Dp
+
+	kit *UIKit
+}
+
+// Card component with shadow and consistent styling, stretched across the
+// available width
+func (kit *UIKit) Card(gtx layout.Context, content layout.Widget) layout.Dimensions {
+	return kit.ElevatedCard(gtx, Elevation1, content)
+}
+
+// ElevatedCard is a Card raised to the given elevation. Flat cards at
+// Elevation0 are outlined instead.
+func (kit *UIKit) ElevatedCard(gtx layout.Context, elevation Elevation, content layout.Widget) layout.Dimensions {
+	c := kit.CardStyle(CardElevated, content)
+	c.Elevation = elevation
+	c.FullWidth = true
+	return c.Layout(gtx)
+}
+
+// CardStyle returns a card that can be further configured before layout
+func (kit *UIKit) CardStyle(variant CardVariant, content layout.Widget) CardStyle {
+	return CardStyle{
+		Variant:   variant,
+		Elevation: Elevation1,
+		Content:   content,
+		Padding:   kit.Spacing.Large,
+		kit:       kit,
+	}
+}
+
+// Layout draws the card, sized to its content
+func (c CardStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := c.kit
+	if c.FullWidth {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	}
+	radius := kit.Radius.Large
+	bg, border, elevation := c.colors()
+
+	// Hover and press ease in a state layer and, for elevated cards, lift
+	// the card one level
+	var level float32
+	focused := false
+	if c.Clickable != nil {
+		focused = gtx.Focused(c.Clickable)
+		switch {
+		case c.Clickable.Pressed():
+			level = 2
+		case c.Clickable.Hovered(), focused:
+			level = 1
+		}
+		tw := kit.tween(gtx, c.Clickable)
+		tw.Duration = kit.Motion.Duration(kit.Motion.Fast)
+		tw.To(level)
+		level = tw.Value(gtx)
+		if elevation > 0 {
+			elevation += min(level, 1)
+		}
+	}
+	var layer uint8
+	if level <= 1 {
+		layer = uint8(hoverLayerAlpha * level)
+	} else {
+		layer = uint8(hoverLayerAlpha + (pressedLayerAlpha-hoverLayerAlpha)*(level-1))
+	}
+
+	background := func(gtx layout.Context) layout.Dimensions {
+		size := gtx.Constraints.Min
+		rr := gtx.Dp(radius)
+		kit.paintShadow(gtx, size, rr, elevation)
+		shape := clip.UniformRRect(image.Rectangle{Max: size}, rr)
+		paint.FillShape(gtx.Ops, bg, shape.Op(gtx.Ops))
+		if layer > 0 {
+			paint.FillShape(gtx.Ops, withAlpha(kit.Colors.OnSurface, layer), shape.Op(gtx.Ops))
+		}
+		if border.A > 0 {
+			kit.strokeRRect(gtx, size, radius, unit.Dp(1), border)
+		}
+		if focused {
+			kit.strokeRRect(gtx, size, radius, unit.Dp(2), kit.Colors.Focus)
+		}
+		return layout.Dimensions{Size: size}
+	}
+
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			if c.Clickable == nil {
+				return background(gtx)
+			}
+			// The card's click area sits beneath its content, so buttons in
+			// the card take their own clicks
+			return c.Clickable.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				semantic.Button.Add(gtx.Ops)
+				if c.Description != "" {
+					semantic.DescriptionOp(c.Description).Add(gtx.Ops)
+				}
+				return background(gtx)
+			})
+		}),
+		layout.Stacked(c.layoutSlots),
+	)
+}
+
+// cardSlot is a slot laid out ahead of its place in the card
+type cardSlot struct {
+	call op.CallOp
+	dims layout.Dimensions
+}
+
+func recordSlot(gtx layout.Context, w layout.Widget) cardSlot {
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx)
+	return cardSlot{call: macro.Stop(), dims: dims}
+}
+
+// layoutSlots lays out the media, header, content and footer top to bottom,
+// each exactly once so their widgets see every event and animation frame
+// once. The media and content go first and set the card's width, which the
+// header and footer then stretch to, pushing their actions to the end.
+func (c CardStyle) layoutSlots(gtx layout.Context) layout.Dimensions {
+	cs := gtx.Constraints
+	pad := gtx.Dp(c.Padding)
+	gap := gtx.Dp(c.kit.Spacing.Medium)
+	hasHeader := c.Title != "" || c.Subtitle != "" || c.Avatar != nil || c.Action != nil
+
+	var media, header, content, footer cardSlot
+	if c.Media != nil {
+		gtx.Constraints.Min.Y = 0
+		media = recordSlot(gtx, c.layoutMedia)
+	}
+	// Slots below the media are inset by the padding and share the height
+	// left over
+	remaining := max(cs.Max.Y-media.dims.Size.Y-2*pad, 0)
+	inner := func(minX int) layout.Context {
+		gtx := gtx
+		gtx.Constraints.Max = image.Pt(max(cs.Max.X-2*pad, 0), remaining)
+		gtx.Constraints.Min = image.Pt(min(max(minX-2*pad, 0), gtx.Constraints.Max.X), 0)
+		return gtx
+	}
+	width := max(cs.Min.X, media.dims.Size.X)
+	if c.Content != nil {
+		content = recordSlot(inner(width), c.Content)
+		remaining = max(remaining-content.dims.Size.Y-gap, 0)
+		width = max(width, content.dims.Size.X+2*pad)
+	}
+	if hasHeader {
+		header = recordSlot(inner(width), c.layoutHeader)
+		remaining = max(remaining-header.dims.Size.Y-gap, 0)
+	}
+	if len(c.Footer) > 0 {
+		footer = recordSlot(inner(width), c.layoutFooter)
+	}
+	width = max(width, header.dims.Size.X+2*pad, footer.dims.Size.X+2*pad)
+
+	var body []cardSlot
+	if hasHeader {
+		body = append(body, header)
+	}
+	if c.Content != nil {
+		body = append(body, content)
+	}
+	if len(c.Footer) > 0 {
+		body = append(body, footer)
+	}
+	media.call.Add(gtx.Ops)
+	y := media.dims.Size.Y
+	if len(body) > 0 {
+		y += pad
+		for i, s := range body {
+			if i > 0 {
+				y += gap
+			}
+			trans := op.Offset(image.Pt(pad, y)).Push(gtx.Ops)
+			s.call.Add(gtx.Ops)
+			trans.Pop()
+			y += s.dims.Size.Y
+		}
+		y += pad
+	}
+	return layout.Dimensions{Size: cs.Constrain(image.Pt(width, y))}
+}
+
+// layoutMedia draws the media clipped to the card's top corners
+func (c CardStyle) layoutMedia(gtx layout.Context) layout.Dimensions {
+	macro := op.Record(gtx.Ops)
+	dims := c.Media(gtx)
+	call := macro.Stop()
+
+	r := gtx.Dp(c.kit.Radius.Large)
+	defer clip.RRect{Rect: image.Rectangle{Max: dims.Size}, NW: r, NE: r}.Push(gtx.Ops).Pop()
+	call.Add(gtx.Ops)
+	return dims
+}
+
+// layoutHeader lays out the avatar, title and subtitle, and action in a
+// row, with the action at the end. The action goes first so that long
+// titles wrap before it rather than pushing it out.
+func (c CardStyle) layoutHeader(gtx layout.Context) layout.Dimensions {
+	kit := c.kit
+	fg, secondary := kit.Colors.TextPrimary, kit.Colors.OnSurfaceVariant
+	cs := gtx.Constraints
+	gtx.Constraints.Min = image.Point{}
+
+	var action cardSlot
+	if c.Action != nil {
+		action = recordSlot(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: kit.Spacing.Small}.Layout(gtx, c.Action)
+		})
+	}
+	gtx.Constraints.Max.X = max(cs.Max.X-action.dims.Size.X, 0)
+	lead := recordSlot(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if c.Avatar == nil {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Right: kit.Spacing.Medium}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					gtx.Constraints = layout.Exact(image.Pt(gtx.Dp(cardAvatarSize), gtx.Dp(cardAvatarSize)))
+					return c.Avatar(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if c.Title == "" {
+							return layout.Dimensions{}
+						}
+						return kit.Label(c.Title, kit.Typography.TitleMedium, fg).Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if c.Subtitle == "" {
+							return layout.Dimensions{}
+						}
+						return kit.Label(c.Subtitle, kit.Typography.BodySmall, secondary).Layout(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	size := cs.Constrain(image.Pt(
+		lead.dims.Size.X+action.dims.Size.X,
+		max(lead.dims.Size.Y, action.dims.Size.Y),
+	))
+	trans := op.Offset(image.Pt(0, (size.Y-lead.dims.Size.Y)/2)).Push(gtx.Ops)
+	lead.call.Add(gtx.Ops)
+	trans.Pop()
+	trans = op.Offset(image.Pt(size.X-action.dims.Size.X, (size.Y-action.dims.Size.Y)/2)).Push(gtx.Ops)
+	action.call.Add(gtx.Ops)
+	trans.Pop()
+	return layout.Dimensions{Size: size}
+}
+
+// layoutFooter lays out the footer widgets right-aligned
+func (c CardStyle) layoutFooter(gtx layout.Context) layout.Dimensions {
+	var children []layout.FlexChild
+	for i, w := range c.Footer {
+		if i > 0 {
+			children = append(children, layout.Rigid(layout.Spacer{Width: c.kit.Spacing.Small}.Layout))
+		}
+		children = append(children, layout.Rigid(w))
+	}
+	return layout.Flex{Alignment: layout.Middle, Spacing: layout.SpaceStart}.Layout(gtx, children...)
+}
+
+// colors returns the background, border and elevation for the variant
+func (c CardStyle) colors() (bg, border color.NRGBA, elevation float32) {
+	colors := c.kit.Colors
+	switch c.Variant {
+	case CardOutlined:
+		return colors.Surface, colors.Border, 0
+	case CardFilled:
+		return colors.Gray100, color.NRGBA{}, 0
+	default:
+		if c.Elevation == Elevation0 {
+			return colors.Surface, colors.BorderLight, 0
+		}
+		return colors.Surface, color.NRGBA{}, float32(c.Elevation)
+	}
+}
@@ -0,0 +1,67 @@
+package uikit
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+)
+
+func TestCardWidth(t *testing.T) {
+	kit := NewUIKit()
+	gtx := layout.Context{
+		Ops:         new(op.Ops),
+		Metric:      unit.Metric{PxPerDp: 1, PxPerSp: 1},
+		Constraints: layout.Constraints{Max: image.Pt(1000, 1000)},
+	}
+	fixed := func(w int) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(w, 20)}
+		}
+	}
+	c := kit.CardStyle(CardOutlined, fixed(200))
+	c.Title = "Title"
+	c.Action = fixed(24)
+	c.Footer = []layout.Widget{fixed(60)}
+	c.Padding = 10
+
+	if got := c.Layout(gtx).Size.X; got != 220 {
+		t.Errorf("card is %d wide; want its content's 220", got)
+	}
+	c.FullWidth = true
+	if got := c.Layout(gtx).Size.X; got != 1000 {
+		t.Errorf("full width card is %d wide; want 1000", got)
+	}
+}
+
+func TestCardSlotsLaidOutOnce(t *testing.T) {
+	kit := NewUIKit()
+	gtx := layout.Context{
+		Ops:         new(op.Ops),
+		Metric:      unit.Metric{PxPerDp: 1, PxPerSp: 1},
+		Constraints: layout.Constraints{Max: image.Pt(1000, 1000)},
+	}
+	calls := make(map[string]int)
+	counted := func(name string, w int) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			calls[name]++
+			return layout.Dimensions{Size: image.Pt(w, 20)}
+		}
+	}
+	for _, full := range []bool{false, true} {
+		clear(calls)
+		c := kit.CardStyle(CardElevated, counted("content", 200))
+		c.Media = counted("media", 120)
+		c.Action = counted("action", 24)
+		c.Footer = []layout.Widget{counted("footer", 60)}
+		c.FullWidth = full
+		c.Layout(gtx)
+		for _, name := range []string{"content", "media", "action", "footer"} {
+			if calls[name] != 1 {
+				t.Errorf("FullWidth %v: %s laid out %d times; want once", full, name, calls[name])
+			}
+		}
+	}
+}
@@ -0,0 +1,537 @@
+package uikit
+
+import (
+	"context"
+	"image"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gioui.org/font"
+	"gioui.org/gesture"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// SuggestFunc looks up suggestions for the text typed so far, off the UI
+// goroutine, e.g. on a server. The context is cancelled when the text
+// changes again.
+type SuggestFunc func(ctx context.Context, query string) ([]Suggestion, error)
+
+// Suggestion is an entry in a Combobox's list
+type Suggestion struct {
+	// Key identifies the suggestion, such as a user id
+	Key string
+	// Label is what choosing the suggestion puts in the input
+	Label string
+	// Detail is drawn after the label in a quieter color, such as an email
+	// address
+	Detail string
+}
+
+// Combobox holds the text of an input that suggests values as the user
+// types, and runs the lookups
+type Combobox struct {
+	widget.Editor
+	Suggest SuggestFunc
+	// Debounce is how long typing must pause before Suggest runs; zero
+	// means DefaultSearchDebounce
+	Debounce time.Duration
+	// Invalidate is called when suggestions arrive, to wake the UI; usually
+	// set to the app window's Invalidate method
+	Invalidate func()
+
+	// Shared with the lookup goroutine
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	loading  bool
+	results  []Suggestion
+	err      error
+	arrived  bool // Results came in that the UI has not taken yet
+	answered bool // The lookup for the current text finished
+
+	// As of the last layout
+	restricted bool
+	minChars   int
+	window     *windowState
+
+	text        string
+	suggestions []Suggestion
+	failed      bool
+	selected    Suggestion // Last suggestion chosen
+	showing     bool       // The text is still the selected suggestion's
+	chosen      bool       // A suggestion was chosen since the last Update
+	focused     bool
+	open        bool
+	highlight   int // Suggestion the keyboard is on, or -1
+	reveal      bool
+	anchor      popupAnchor
+	clicks      []gesture.Click
+	scrim       gesture.Click
+	list        layout.List
+}
+
+// Selected returns the suggestion last chosen, and whether the input
+// still shows it
+func (s *Combobox) Selected() (Suggestion, bool) {
+	return s.selected, s.showing
+}
+
+// Loading reports whether a lookup is waiting or running
+func (s *Combobox) Loading() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loading
+}
+
+// Update handles input since the last call and reports whether the user
+// chose a suggestion. Down opens the list and moves through it, Up moves
+// back, Enter chooses and Escape closes it.
+func (s *Combobox) Update(gtx layout.Context) bool {
+	s.anchor.locate(gtx, s.window)
+	for {
+		e, ok := s.scrim.Update(gtx.Source)
+		if !ok {
+			break
+		}
+		if e.Kind == gesture.KindPress {
+			s.open = false
+		}
+	}
+	for i := range s.clicks {
+		for {
+			e, ok := s.clicks[i].Update(gtx.Source)
+			if !ok {
+				break
+			}
+			if e.Kind == gesture.KindClick && s.open && i < len(s.suggestions) {
+				s.choose(i)
+			}
+		}
+	}
+
+	// Take the list's keys before the editor sees them
+	ed := &s.Editor
+	filters := []event.Filter{
+		key.Filter{Focus: ed, Name: key.NameDownArrow},
+		key.Filter{Focus: ed, Name: key.NameUpArrow},
+	}
+	if s.open {
+		filters = append(filters, key.Filter{Focus: ed, Name: key.NameEscape})
+	}
+	if s.open && s.highlight >= 0 {
+		filters = append(filters,
+			key.Filter{Focus: ed, Name: key.NameReturn},
+			key.Filter{Focus: ed, Name: key.NameEnter},
+		)
+	}
+	for {
+		ev, ok := gtx.Event(filters...)
+		if !ok {
+			break
+		}
+		e, ok := ev.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+		switch e.Name {
+		case key.NameDownArrow:
+			if !s.open {
+				s.open = true
+				if s.suggestions == nil && !s.Loading() {
+					s.edited(s.text)
+				}
+			} else {
+				s.move(1)
+			}
+		case key.NameUpArrow:
+			s.move(-1)
+		case key.NameEscape:
+			s.open = false
+		default:
+			s.choose(s.highlight)
+		}
+	}
+
+	// Apply this frame's typing now, so the lookup starts without waiting
+	// for another frame
+	for {
+		if _, ok := s.Editor.Update(gtx); !ok {
+			break
+		}
+	}
+	if text := s.Text(); text != s.text {
+		s.text = text
+		s.edited(text)
+	}
+	focused := gtx.Focused(ed)
+	if s.focused && !focused {
+		s.blur()
+	}
+	s.focused = focused
+
+	s.mu.Lock()
+	if s.arrived {
+		s.arrived = false
+		s.suggestions, s.failed = s.results, s.err != nil
+		s.highlight = -1
+		if s.restricted && len(s.suggestions) > 0 {
+			s.highlight = 0
+		}
+		s.list.Position = layout.Position{}
+	}
+	s.mu.Unlock()
+
+	chosen := s.chosen
+	s.chosen = false
+	return chosen
+}
+
+// edited looks up suggestions for new text typed into the input
+func (s *Combobox) edited(text string) {
+	s.showing = s.showing && text == s.selected.Label
+	if !s.focused {
+		return
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(text)) < s.minChars {
+		s.stop()
+		s.suggestions, s.open = nil, false
+		return
+	}
+	s.open = true
+	s.request(text)
+}
+
+// request cancels any running lookup and starts one for query once the
+// debounce delay has passed
+func (s *Combobox) request(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.answered = false
+	if s.Suggest == nil {
+		s.loading = false
+		return
+	}
+	s.loading = true
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	delay := s.Debounce
+	if delay == 0 {
+		delay = DefaultSearchDebounce
+	}
+	suggest := s.Suggest
+	go func() {
+		defer cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		results, err := suggest(ctx, query)
+
+		s.mu.Lock()
+		if ctx.Err() != nil {
+			s.mu.Unlock()
+			return
+		}
+		s.results, s.err = results, err
+		s.loading, s.arrived, s.answered = false, true, true
+		invalidate := s.Invalidate
+		s.mu.Unlock()
+		if invalidate != nil {
+			invalidate()
+		}
+	}()
+}
+
+// stop cancels any lookup in progress
+func (s *Combobox) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.loading, s.arrived = false, false
+}
+
+// choose puts suggestion i in the input and closes the list
+func (s *Combobox) choose(i int) {
+	if i < 0 || i >= len(s.suggestions) {
+		return
+	}
+	s.selected, s.showing, s.chosen = s.suggestions[i], true, true
+	s.setText(s.selected.Label)
+	s.open = false
+	s.stop()
+}
+
+// setText replaces the text without looking it up
+func (s *Combobox) setText(text string) {
+	s.SetText(text)
+	n := s.Len()
+	s.SetCaret(n, n)
+	s.text = text
+}
+
+// blur closes the list as the input loses focus. A restricted input takes
+// the suggestion matching its text, or goes back to the last one chosen.
+func (s *Combobox) blur() {
+	s.open = false
+	if !s.restricted || s.showing {
+		return
+	}
+	for i, sg := range s.suggestions {
+		if strings.EqualFold(sg.Label, strings.TrimSpace(s.text)) {
+			s.choose(i)
+			return
+		}
+	}
+	s.stop()
+	s.setText(s.selected.Label)
+	s.showing = s.selected != Suggestion{}
+}
+
+// move moves the keyboard highlight. In a free text input it can move back
+// off the list to leave the typed text as it is.
+func (s *Combobox) move(dir int) {
+	if len(s.suggestions) == 0 {
+		return
+	}
+	lowest := -1
+	if s.restricted {
+		lowest = 0
+	}
+	s.highlight = max(lowest, min(len(s.suggestions)-1, s.highlight+dir))
+	s.reveal = true
+}
+
+// ComboboxStyle is an input with a list of suggestions for what is typed
+// in it, looked up by the state's Suggest function. The list stays inside
+// the window when the app lays out kit.TrackWindow, with the same limits
+// as a Select's menu.
+type ComboboxStyle struct {
+	InputStyle
+	State *Combobox
+	// Restricted inputs only take suggestions: on losing focus, text that
+	// is not one goes back to the suggestion chosen last
+	Restricted bool
+	// MinChars is how many characters must be typed before a lookup
+	MinChars int
+}
+
+// Combobox is a free text input with suggestions and a floating label
+func (kit *UIKit) Combobox(state *Combobox, label, hint string) layout.Widget {
+	return kit.ComboboxStyle(state, label, hint).Layout
+}
+
+// ComboboxStyle returns a combobox that can be further configured before
+// layout
+func (kit *UIKit) ComboboxStyle(state *Combobox, label, hint string) ComboboxStyle {
+	in := kit.InputStyle(&state.Editor, hint)
+	in.Label = label
+	in.LabelPlacement = InputLabelFloating
+	return ComboboxStyle{InputStyle: in, State: state, MinChars: 1}
+}
+
+// Layout draws the input with a spinner while looking up, and the list of
+// suggestions while it is open
+func (c ComboboxStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := c.State
+	s.SingleLine = true
+	s.restricted, s.minChars = c.Restricted, max(c.MinChars, 0)
+	s.window = &c.kit.window
+	s.anchor.avail = gtx.Constraints.Max.Y
+	s.Update(gtx)
+	if n := len(s.suggestions); len(s.clicks) < n {
+		s.clicks = append(s.clicks, make([]gesture.Click, n-len(s.clicks))...)
+	}
+
+	in := c.InputStyle
+	if s.Loading() && in.Trailing == nil {
+		in.Trailing = c.layoutSpinner
+	}
+	in.overlay = c.layoutOverlay
+	return in.Layout(gtx)
+}
+
+func (c ComboboxStyle) layoutSpinner(gtx layout.Context) layout.Dimensions {
+	kit := c.kit
+	spinner := kit.CircularProgressStyle(0)
+	spinner.Indeterminate = true
+	spinner.Size = gtx.Metric.PxToDp(gtx.Sp(kit.Typography.BodyLarge.LineHeight))
+	spinner.Thickness = unit.Dp(2)
+	return spinner.Layout(gtx)
+}
+
+// layoutOverlay tracks the pointer to place the input in the window and
+// hangs the list from it. Clicks around the list close it and still reach
+// the input.
+func (c ComboboxStyle) layoutOverlay(gtx layout.Context) layout.Dimensions {
+	s := c.State
+	size := gtx.Constraints.Min
+	s.anchor.size = size
+	if gtx.Enabled() {
+		s.anchor.track(gtx)
+	}
+	if s.open && s.focused && !c.Disabled && !c.ReadOnly {
+		c.kit.layoutPopup(gtx, s.anchor, &s.scrim, true, c.layoutList)
+	}
+	return layout.Dimensions{Size: size}
+}
+
+// layoutList draws the suggestions, or a line saying why there are none
+func (c ComboboxStyle) layoutList(gtx layout.Context) layout.Dimensions {
+	s := c.State
+	kit := c.kit
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(selectMenuMaxHeight))
+
+	status, col := "", kit.Colors.TextSecondary
+	s.mu.Lock()
+	loading, answered := s.loading, s.answered
+	s.mu.Unlock()
+	switch {
+	case s.failed:
+		status, col = "Couldn't load suggestions", kit.Colors.Error
+	case len(s.suggestions) > 0:
+	case loading:
+		status = "Searching..."
+	case answered:
+		status = "No matches"
+	default:
+		return layout.Dimensions{}
+	}
+	if s.reveal {
+		s.reveal = false
+		revealRow(&s.list, s.highlight)
+	}
+
+	radius := kit.Radius.Medium
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			kit.PaintShadow(gtx, size, radius, Elevation2)
+			defer clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(radius)).Push(gtx.Ops).Pop()
+			// Keep presses on the list's padding from reaching the scrim
+			event.Op(gtx.Ops, &s.list)
+			paint.Fill(gtx.Ops, kit.Colors.SurfaceElevated)
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: kit.Spacing.Tiny, Bottom: kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				if status != "" {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.Inset{
+						Top: kit.Spacing.Small, Bottom: kit.Spacing.Small,
+						Left: kit.Spacing.Medium, Right: kit.Spacing.Medium,
+					}.Layout(gtx, kit.Label(status, kit.Typography.BodyMedium, col).Layout)
+				}
+				s.list.Axis = layout.Vertical
+				return s.list.Layout(gtx, len(s.suggestions), c.layoutSuggestion)
+			})
+		}),
+	)
+}
+
+// layoutSuggestion draws a suggestion with the typed text picked out in
+// bold
+func (c ComboboxStyle) layoutSuggestion(gtx layout.Context, i int) layout.Dimensions {
+	s := c.State
+	kit := c.kit
+	sg := s.suggestions[i]
+	typo := kit.Typography.BodyLarge
+
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	macro := op.Record(gtx.Ops)
+	dims := layout.Inset{
+		Top: kit.Spacing.Small, Bottom: kit.Spacing.Small,
+		Left: kit.Spacing.Medium, Right: kit.Spacing.Medium,
+	}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		span := func(text string, weight font.Weight) layout.FlexChild {
+			return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if text == "" {
+					return layout.Dimensions{}
+				}
+				l := kit.Label(text, typo, kit.Colors.OnSurface)
+				l.Font.Weight = weight
+				l.MaxLines = 1
+				return l.Layout(gtx)
+			})
+		}
+		var children []layout.FlexChild
+		if start, end := matchFold(sg.Label, strings.TrimSpace(s.text)); end > start {
+			children = append(children,
+				span(sg.Label[:start], typo.Weight),
+				span(sg.Label[start:end], font.Bold),
+				span(sg.Label[end:], typo.Weight),
+			)
+		} else {
+			children = append(children, span(sg.Label, typo.Weight))
+		}
+		if sg.Detail != "" {
+			children = append(children, layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: kit.Spacing.Small}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					l := kit.Label(sg.Detail, kit.Typography.BodyMedium, kit.Colors.TextSecondary)
+					l.MaxLines = 1
+					return l.Layout(gtx)
+				})
+			}))
+		}
+		return layout.Flex{Alignment: layout.Baseline}.Layout(gtx, children...)
+	})
+	call := macro.Stop()
+
+	rect := image.Rectangle{Max: dims.Size}
+	if i == s.highlight || s.clicks[i].Hovered() {
+		alpha := uint8(hoverLayerAlpha)
+		if i == s.highlight {
+			alpha = focusLayerAlpha
+		}
+		paint.FillShape(gtx.Ops, withAlpha(kit.Colors.OnSurface, alpha), clip.Rect(rect).Op())
+	}
+	call.Add(gtx.Ops)
+
+	defer clip.Rect(rect).Push(gtx.Ops).Pop()
+	semantic.LabelOp(sg.Label).Add(gtx.Ops)
+	semantic.SelectedOp(i == s.highlight).Add(gtx.Ops)
+	s.clicks[i].Add(gtx.Ops)
+	pointer.CursorPointer.Add(gtx.Ops)
+	return dims
+}
+
+// matchFold returns the byte range of the first match of sub in s, ignoring
+// case, or an empty range if there is none
+func matchFold(s, sub string) (start, end int) {
+	if sub == "" {
+		return 0, 0
+	}
+	for i := range s {
+		j := i
+		matched := true
+		for _, r := range sub {
+			c, size := utf8.DecodeRuneInString(s[j:])
+			if size == 0 || !strings.EqualFold(string(c), string(r)) {
+				matched = false
+				break
+			}
+			j += size
+		}
+		if matched {
+			return i, j
+		}
+	}
+	return 0, 0
+}
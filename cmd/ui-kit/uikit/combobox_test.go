@@ -0,0 +1,21 @@
+package uikit
+
+import "testing"
+
+func TestMatchFold(t *testing.T) {
+	for _, tc := range []struct {
+		s, sub     string
+		start, end int
+	}{
+		{"Jane Cooper", "coo", 5, 8},
+		{"Jane Cooper", "JANE", 0, 4},
+		{"Zoë Ünal", "ün", 5, 8},
+		{"Jane Cooper", "xyz", 0, 0},
+		{"Jane", "", 0, 0},
+	} {
+		start, end := matchFold(tc.s, tc.sub)
+		if start != tc.start || end != tc.end {
+			t.Errorf("matchFold(%q, %q) = %d, %d; want %d, %d", tc.s, tc.sub, start, end, tc.start, tc.end)
+		}
+	}
+}
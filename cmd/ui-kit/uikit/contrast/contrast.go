@@ -0,0 +1,283 @@
+// Package contrast audits UI kit palettes against the WCAG 2.x contrast
+// requirements, using the foreground/background pairings the kit's
+// components actually draw.
+package contrast
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"reflect"
+	"strings"
+
+	"uikit/uikit"
+	"uikit/uikit/internal/srgb"
+)
+
+// WCAG 2.x minimum contrast ratios
+const (
+	MinText      = 4.5 // Normal text, level AA
+	MinLargeText = 3.0 // Text at 18pt, or 14pt bold, and above
+	MinGraphics  = 3.0 // Icons, focus rings and other non-text UI
+	MinTextAAA   = 7.0 // Normal text, level AAA
+)
+
+// Pair names a foreground/background pairing by ColorPalette field
+type Pair struct {
+	Usage      string // Where the kit draws this pairing
+	Foreground string
+	Background string
+	Min        float64
+}
+
+// Pairs lists every pairing drawn by the kit components
+var Pairs = []Pair{
+	// Text
+	{"Text on background", "TextPrimary", "Background", MinText},
+	{"Secondary text on background", "TextSecondary", "Background", MinText},
+	{"Text on card", "TextPrimary", "Surface", MinText},
+	{"Secondary text on card", "TextSecondary", "Surface", MinText},
+	{"Text on surface", "OnSurface", "Surface", MinText},
+	{"Text on background (On color)", "OnBackground", "Background", MinText},
+	{"Text on elevated surface", "OnSurfaceElevated", "SurfaceElevated", MinText},
+	{"Variant text on surface", "OnSurfaceVariant", "Surface", MinText},
+	{"Text on filled card", "TextPrimary", "Gray100", MinText},
+	{"Card subtitle", "OnSurfaceVariant", "Surface", MinText},
+	{"Card subtitle on filled card", "OnSurfaceVariant", "Gray100", MinText},
+	{"Input hint", "TextSecondary", "Surface", MinText},
+	{"Error message", "Error", "Surface", MinText},
+
+	// Buttons
+	{"Primary button", "OnPrimary", "Primary500", MinText},
+	{"Primary button, hovered", "OnPrimary", "Primary600", MinText},
+	{"Primary button, pressed", "OnPrimary", "Primary700", MinText},
+	{"Secondary button", "OnSecondary", "Gray100", MinText},
+	{"Secondary button, hovered", "OnSecondary", "Gray200", MinText},
+	{"Secondary button, pressed", "OnSecondary", "Gray300", MinText},
+	{"Outline and ghost buttons", "Primary500", "Surface", MinText},
+	{"Danger button", "OnError", "Error", MinText},
+	{"Success button", "OnSuccess", "Success", MinText},
+	{"Warning fill", "OnWarning", "Warning", MinText},
+	{"Info fill", "OnInfo", "Info", MinText},
+
+	// Tabs
+	{"Tab", "TextSecondary", "Background", MinText},
+	{"Selected tab", "Primary500", "Background", MinText},
+	{"Selected pill tab", "OnPrimary", "Primary500", MinText},
+
+	// Badges
+	{"Default badge", "TextPrimary", "Gray200", MinText},
+	{"Success badge", "OnSuccessLight", "SuccessLight", MinText},
+	{"Warning badge", "OnWarningLight", "WarningLight", MinText},
+	{"Error badge", "OnErrorLight", "ErrorLight", MinText},
+	{"Info badge", "OnInfoLight", "InfoLight", MinText},
+
+	// Alerts
+	{"Info alert text", "OnSurface", "InfoLight", MinText},
+	{"Success alert text", "OnSurface", "SuccessLight", MinText},
+	{"Warning alert text", "OnSurface", "WarningLight", MinText},
+	{"Error alert text", "OnSurface", "ErrorLight", MinText},
+	{"Info alert icon", "Info", "InfoLight", MinGraphics},
+	{"Success alert icon", "Success", "SuccessLight", MinGraphics},
+	{"Warning alert icon", "Warning", "WarningLight", MinGraphics},
+	{"Error alert icon", "Error", "ErrorLight", MinGraphics},
+
+	// Toasts
+	{"Toast action", "Primary500", "SurfaceElevated", MinText},
+	{"Info toast icon", "Info", "SurfaceElevated", MinGraphics},
+	{"Success toast icon", "Success", "SurfaceElevated", MinGraphics},
+	{"Warning toast icon", "Warning", "SurfaceElevated", MinGraphics},
+	{"Error toast icon", "Error", "SurfaceElevated", MinGraphics},
+
+	// Progress
+	{"Progress fill on track", "Primary500", "Gray200", MinGraphics},
+	{"Success progress on track", "Success", "Gray200", MinGraphics},
+	{"Warning progress on track", "Warning", "Gray200", MinGraphics},
+	{"Error progress on track", "Error", "Gray200", MinGraphics},
+}
+
+// Result is the outcome of checking one pairing
+type Result struct {
+	Pair
+	Fg, Bg color.NRGBA
+	Ratio  float64 // WCAG 2.x contrast ratio, 1 to 21
+	APCA   float64 // APCA lightness contrast (Lc), informational only
+}
+
+// Pass reports whether the pairing meets its minimum ratio
+func (r Result) Pass() bool {
+	return r.Ratio >= r.Min
+}
+
+// Report holds the results of auditing a palette
+type Report struct {
+	Results []Result
+}
+
+// Passed reports whether every pairing meets its minimum ratio
+func (r Report) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the pairings below their minimum ratio
+func (r Report) Failures() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if !res.Pass() {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	for _, res := range r.Results {
+		status := "ok  "
+		if !res.Pass() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "%s %-32s %-18s on %-16s %5.2f:1 (min %.1f, Lc %5.1f)\n",
+			status, res.Usage, res.Foreground, res.Background, res.Ratio, res.Min, res.APCA)
+	}
+	return b.String()
+}
+
+// Audit checks every pairing in Pairs against the palette
+func Audit(p uikit.ColorPalette) Report {
+	return AuditPairs(p, Pairs)
+}
+
+// AuditPairs checks the given pairings against the palette. It panics if a
+// pair names a field that ColorPalette does not have.
+func AuditPairs(p uikit.ColorPalette, pairs []Pair) Report {
+	report := Report{Results: make([]Result, len(pairs))}
+	for i, pair := range pairs {
+		fg, bg := field(&p, pair.Foreground).Interface().(color.NRGBA), field(&p, pair.Background).Interface().(color.NRGBA)
+		report.Results[i] = Result{
+			Pair:  pair,
+			Fg:    fg,
+			Bg:    bg,
+			Ratio: Ratio(fg, bg),
+			APCA:  APCA(fg, bg),
+		}
+	}
+	return report
+}
+
+// Fix darkens or lightens the foreground of every failing pairing until it
+// meets its minimum, and returns the adjusted palette with its new report.
+// It makes at most four passes over the palette. A foreground shared by
+// several pairings is adjusted against each in turn, and fixing one can
+// break another, so some may still fail; check the returned Report.
+func Fix(p uikit.ColorPalette) (uikit.ColorPalette, Report) {
+	for pass := 0; pass < 4; pass++ {
+		failures := Audit(p).Failures()
+		if len(failures) == 0 {
+			break
+		}
+		for _, res := range failures {
+			fg := field(&p, res.Foreground)
+			bg := field(&p, res.Background).Interface().(color.NRGBA)
+			fg.Set(reflect.ValueOf(Adjust(fg.Interface().(color.NRGBA), bg, res.Min)))
+		}
+	}
+	return p, Audit(p)
+}
+
+// Adjust returns fg moved towards black or white, whichever can reach the
+// higher contrast against bg, just far enough to meet min. Hue is kept by
+// mixing in linear light; alpha is preserved.
+func Adjust(fg, bg color.NRGBA, min float64) color.NRGBA {
+	if Ratio(fg, bg) >= min {
+		return fg
+	}
+	target := color.NRGBA{A: fg.A}
+	white := color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: fg.A}
+	if Ratio(white, bg) > Ratio(target, bg) {
+		target = white
+	}
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 20; i++ {
+		mid := (lo + hi) / 2
+		if Ratio(mixLinear(fg, target, mid), bg) >= min {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return mixLinear(fg, target, hi)
+}
+
+// Ratio returns the WCAG 2.x contrast ratio between fg and bg. A translucent
+// foreground is composited over the background first; the background is
+// treated as opaque.
+func Ratio(fg, bg color.NRGBA) float64 {
+	l1, l2 := Luminance(over(fg, bg)), Luminance(bg)
+	return (math.Max(l1, l2) + 0.05) / (math.Min(l1, l2) + 0.05)
+}
+
+// Luminance returns the WCAG 2.x relative luminance of c, ignoring alpha
+func Luminance(c color.NRGBA) float64 {
+	return srgb.Luminance(c)
+}
+
+// APCA returns the APCA-W3 (0.0.98G) lightness contrast Lc of text over bg.
+// Positive values are dark text on a light background, negative values
+// light text on a dark one; |Lc| 60 roughly corresponds to WCAG 4.5:1 for
+// body text.
+func APCA(text, bg color.NRGBA) float64 {
+	yt, yb := apcaY(over(text, bg)), apcaY(bg)
+	if math.Abs(yb-yt) < 0.0005 {
+		return 0
+	}
+	if yb > yt {
+		sapc := (math.Pow(yb, 0.56) - math.Pow(yt, 0.57)) * 1.14
+		if sapc < 0.1 {
+			return 0
+		}
+		return (sapc - 0.027) * 100
+	}
+	sapc := (math.Pow(yb, 0.65) - math.Pow(yt, 0.62)) * 1.14
+	if sapc > -0.1 {
+		return 0
+	}
+	return (sapc + 0.027) * 100
+}
+
+func apcaY(c color.NRGBA) float64 {
+	ch := func(v uint8) float64 { return math.Pow(float64(v)/255, 2.4) }
+	y := 0.2126729*ch(c.R) + 0.7151522*ch(c.G) + 0.0721750*ch(c.B)
+	if y < 0.022 {
+		y += math.Pow(0.022-y, 1.414)
+	}
+	return y
+}
+
+func field(p *uikit.ColorPalette, name string) reflect.Value {
+	f := reflect.ValueOf(p).Elem().FieldByName(name)
+	if !f.IsValid() {
+		panic("contrast: ColorPalette has no field " + name)
+	}
+	return f
+}
+
+// over composites fg onto an opaque bg
+func over(fg, bg color.NRGBA) color.NRGBA {
+	if fg.A == 0xFF {
+		return fg
+	}
+	a := float64(fg.A) / 255
+	blend := func(f, b uint8) uint8 {
+		return uint8(math.Round(float64(f)*a + float64(b)*(1-a)))
+	}
+	return color.NRGBA{R: blend(fg.R, bg.R), G: blend(fg.G, bg.G), B: blend(fg.B, bg.B), A: 0xFF}
+}
+
+// mixLinear interpolates two colors in linear light, keeping a's alpha
+func mixLinear(a, b color.NRGBA, t float64) color.NRGBA {
+	m := func(x, y uint8) uint8 {
+		return srgb.Encode(srgb.Linear(x)*(1-t) + srgb.Linear(y)*t)
+	}
+	return color.NRGBA{R: m(a.R, b.R), G: m(a.G, b.G), B: m(a.B, b.B), A: a.A}
+}
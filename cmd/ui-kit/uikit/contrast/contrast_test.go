@@ -0,0 +1,82 @@
+package contrast
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"uikit/uikit"
+)
+
+func TestRatio(t *testing.T) {
+	black := color.NRGBA{A: 0xFF}
+	white := color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	if got := Ratio(black, white); math.Abs(got-21) > 0.01 {
+		t.Errorf("Ratio(black, white) = %.2f, want 21", got)
+	}
+	if got := Ratio(white, white); got != 1 {
+		t.Errorf("Ratio(white, white) = %.2f, want 1", got)
+	}
+	if got := Ratio(black, white); got != Ratio(white, black) {
+		t.Errorf("Ratio is not symmetric: %.2f vs %.2f", got, Ratio(white, black))
+	}
+}
+
+func TestDefaultPalettes(t *testing.T) {
+	palettes := map[string]uikit.ColorPalette{
+		"light":        uikit.NewColorPalette(),
+		"dark":         uikit.NewDarkColorPalette(),
+		"seeded light": uikit.NewColorPaletteFromSeeds(uikit.DefaultPaletteSeeds()),
+		"seeded dark":  uikit.NewDarkColorPaletteFromSeeds(uikit.DefaultPaletteSeeds()),
+	}
+	for name, p := range palettes {
+		if report := Audit(p); !report.Passed() {
+			t.Errorf("%s palette fails WCAG AA:\n%s", name, Report{Results: report.Failures()})
+		}
+	}
+}
+
+// TestSeededPalettes checks the pairings the generator picks colors for:
+// text and On colors. Brand accents drawn as text, such as Primary500, are
+// only as readable as the seed allows.
+func TestSeededPalettes(t *testing.T) {
+	var promised []Pair
+	for _, pair := range Pairs {
+		if strings.HasPrefix(pair.Foreground, "On") || strings.HasPrefix(pair.Foreground, "Text") {
+			promised = append(promised, pair)
+		}
+	}
+	brands := map[string]color.NRGBA{
+		"violet":  {R: 0x7C, G: 0x3A, B: 0xED, A: 0xFF},
+		"teal":    {R: 0x0D, G: 0x94, B: 0x88, A: 0xFF},
+		"orange":  {R: 0xF9, G: 0x73, B: 0x16, A: 0xFF},
+		"yellow":  {R: 0xFF, G: 0xEB, B: 0x00, A: 0xFF},
+		"magenta": {R: 0xDB, G: 0x27, B: 0x77, A: 0xFF},
+	}
+	for name, primary := range brands {
+		seeds := uikit.PaletteSeeds{Primary: primary}
+		for mode, p := range map[string]uikit.ColorPalette{
+			"light": uikit.NewColorPaletteFromSeeds(seeds),
+			"dark":  uikit.NewDarkColorPaletteFromSeeds(seeds),
+		} {
+			if report := AuditPairs(p, promised); !report.Passed() {
+				t.Errorf("%s %s palette fails WCAG AA:\n%s", name, mode, Report{Results: report.Failures()})
+			}
+		}
+	}
+}
+
+func TestFix(t *testing.T) {
+	p := uikit.NewColorPalette()
+	p.OnPrimary = p.Primary400
+	p.TextSecondary = p.Gray300
+
+	fixed, report := Fix(p)
+	if !report.Passed() {
+		t.Fatalf("Fix left failures:\n%s", Report{Results: report.Failures()})
+	}
+	if fixed.TextPrimary != p.TextPrimary {
+		t.Errorf("Fix changed passing color TextPrimary")
+	}
+}
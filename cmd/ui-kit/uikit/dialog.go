@@ -0,0 +1,357 @@
+package uikit
+
+import (
+	"image"
+
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"uikit/uikit/anim"
+)
+
+const dialogMaxWidth = unit.Dp(560)
+
+// DialogCancel is the action reported when a dialog is dismissed with
+// Escape, a scrim click or an action marked Dismiss
+const DialogCancel = -1
+
+// DialogAction is a button in a dialog's action row
+type DialogAction struct {
+	Text    string
+	Variant ButtonVariant
+	// Dismiss reports the action as DialogCancel, for Cancel buttons
+	Dismiss bool
+}
+
+// Dialog describes the content of a modal dialog
+type Dialog struct {
+	Title string
+	Body  string
+	// Content is drawn below the body, for dialogs that need more than text
+	Content layout.Widget
+	// Actions are laid out right-aligned in order; the first one takes the
+	// keyboard focus when the dialog opens
+	Actions []DialogAction
+	// Persistent dialogs ignore Escape and scrim clicks and can only be
+	// closed through their actions
+	Persistent bool
+}
+
+// Confirm returns a dialog with Cancel and confirm actions. Destructive
+// confirmations use the danger button variant. Cancel comes first, so it
+// holds the initial focus.
+func Confirm(title, body, confirm string, destructive bool) *Dialog {
+	variant := ButtonPrimary
+	if destructive {
+		variant = ButtonDanger
+	}
+	return &Dialog{
+		Title: title,
+		Body:  body,
+		Actions: []DialogAction{
+			{Text: "Cancel", Variant: ButtonOutline, Dismiss: true},
+			{Text: confirm, Variant: variant},
+		},
+	}
+}
+
+// DialogResult reports how a dialog was closed
+type DialogResult struct {
+	Dialog *Dialog
+	// Action is the index of the chosen action, or DialogCancel
+	Action int
+}
+
+// Cancelled reports whether the dialog was dismissed rather than confirmed
+func (r DialogResult) Cancelled() bool {
+	return r.Action == DialogCancel
+}
+
+// Modal shows one dialog at a time above the app content. It holds the
+// dialog's button state and the open/close animation; keep it alongside the
+// other widget state of the app and lay it out last with kit.Modal.
+type Modal struct {
+	dialog  *Dialog
+	buttons []*widget.Clickable
+	scrim   gesture.Click
+	results []DialogResult
+
+	closing bool
+	shown   anim.Tween
+	focus   bool // Focus the first action on the next layout
+}
+
+// Show opens d, replacing any dialog already shown
+func (m *Modal) Show(d *Dialog) {
+	m.dialog = d
+	m.closing = false
+	m.shown.Set(0)
+	m.focus = true
+	m.buttons = m.buttons[:0]
+	for range d.Actions {
+		m.buttons = append(m.buttons, new(widget.Clickable))
+	}
+}
+
+// Close dismisses the current dialog, reporting it as cancelled
+func (m *Modal) Close() {
+	m.close(DialogCancel)
+}
+
+// Visible reports whether a dialog is shown or animating out
+func (m *Modal) Visible() bool {
+	return m.dialog != nil
+}
+
+// Update processes input for the current dialog and returns the next
+// result, if a dialog was closed since the last call
+func (m *Modal) Update(gtx layout.Context) (DialogResult, bool) {
+	if m.dialog != nil && !m.closing {
+		m.update(gtx)
+	}
+	if len(m.results) == 0 {
+		return DialogResult{}, false
+	}
+	r := m.results[0]
+	m.results = m.results[1:]
+	return r, true
+}
+
+func (m *Modal) update(gtx layout.Context) {
+	for i, btn := range m.buttons {
+		if btn.Clicked(gtx) {
+			if m.dialog.Actions[i].Dismiss {
+				m.close(DialogCancel)
+			} else {
+				m.close(i)
+			}
+			return
+		}
+	}
+
+	for {
+		ev, ok := m.scrim.Update(gtx.Source)
+		if !ok {
+			break
+		}
+		if ev.Kind == gesture.KindClick && !m.dialog.Persistent {
+			m.close(DialogCancel)
+			return
+		}
+	}
+
+	// Escape closes the dialog and Tab cycles through its actions, so the
+	// keyboard focus never reaches the content underneath
+	for {
+		ev, ok := gtx.Event(
+			key.Filter{Name: key.NameEscape},
+			key.Filter{Name: key.NameTab, Optional: key.ModShift},
+		)
+		if !ok {
+			break
+		}
+		e, ok := ev.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+		switch e.Name {
+		case key.NameEscape:
+			if !m.dialog.Persistent {
+				m.close(DialogCancel)
+				return
+			}
+		case key.NameTab:
+			m.cycleFocus(gtx, e.Modifiers.Contain(key.ModShift))
+		}
+	}
+
+	// Swallow presses on the dialog itself so they don't reach the scrim
+	for {
+		if _, ok := gtx.Event(pointer.Filter{Target: &m.dialog, Kinds: pointer.Press}); !ok {
+			break
+		}
+	}
+}
+
+// cycleFocus moves the focus to the next or previous action, wrapping around
+func (m *Modal) cycleFocus(gtx layout.Context, backward bool) {
+	n := len(m.buttons)
+	if n == 0 {
+		return
+	}
+	next := 0
+	if backward {
+		next = n - 1
+	}
+	for i, btn := range m.buttons {
+		if gtx.Focused(btn) {
+			if backward {
+				next = (i + n - 1) % n
+			} else {
+				next = (i + 1) % n
+			}
+			break
+		}
+	}
+	gtx.Execute(key.FocusCmd{Tag: m.buttons[next]})
+}
+
+func (m *Modal) close(action int) {
+	if m.dialog == nil || m.closing {
+		return
+	}
+	m.results = append(m.results, DialogResult{Dialog: m.dialog, Action: action})
+	m.closing = true
+}
+
+// progress returns how far the dialog has animated in, from 0 to 1, and
+// clears a dialog that has finished animating out
+func (m *Modal) progress(gtx layout.Context, motion Motion) float32 {
+	// Ease out when entering and in when leaving, which is quicker
+	if m.closing {
+		m.shown.Duration = motion.Duration(motion.Fast)
+		m.shown.Easing = anim.EaseIn
+		m.shown.To(0)
+	} else {
+		m.shown.Duration = motion.Duration(motion.Medium)
+		m.shown.Easing = anim.EaseOut
+		m.shown.To(1)
+	}
+	p := m.shown.Value(gtx)
+	if m.closing && p <= 0 {
+		m.dialog = nil
+		gtx.Execute(key.FocusCmd{})
+	}
+	return p
+}
+
+// Modal draws the current dialog of m, if any, above everything laid out
+// before it. Call it last, with the constraints of the whole window.
+func (kit *UIKit) Modal(m *Modal) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		if m.dialog == nil {
+			return layout.Dimensions{}
+		}
+		if !m.closing {
+			m.update(gtx)
+		}
+		if m.dialog == nil {
+			return layout.Dimensions{}
+		}
+		size := gtx.Constraints.Max
+		p := m.progress(gtx, kit.Motion)
+		if m.dialog == nil {
+			return layout.Dimensions{}
+		}
+
+		// The scrim takes every pointer event not meant for the dialog
+		scrim := clip.Rect{Max: size}.Push(gtx.Ops)
+		if !m.closing {
+			m.scrim.Add(gtx.Ops)
+		}
+		overlay := kit.Colors.Overlay
+		overlay.A = uint8(float32(overlay.A) * p)
+		paint.Fill(gtx.Ops, overlay)
+		scrim.Pop()
+
+		gtx.Constraints.Min = image.Point{}
+		return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Max.X = min(gtx.Constraints.Max.X-2*gtx.Dp(kit.Spacing.Large), gtx.Dp(dialogMaxWidth))
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+
+			// Record the dialog to scale it about its center as it fades in
+			macro := op.Record(gtx.Ops)
+			dims := kit.layoutDialog(gtx, m)
+			call := macro.Stop()
+
+			center := f32.Pt(float32(dims.Size.X)/2, float32(dims.Size.Y)/2)
+			scale := 0.95 + 0.05*p
+			defer op.Affine(f32.Affine2D{}.Scale(center, f32.Pt(scale, scale))).Push(gtx.Ops).Pop()
+			defer paint.PushOpacity(gtx.Ops, p).Pop()
+			call.Add(gtx.Ops)
+			return dims
+		})
+	}
+}
+
+func (kit *UIKit) layoutDialog(gtx layout.Context, m *Modal) layout.Dimensions {
+	d := m.dialog
+	if m.focus && len(m.buttons) > 0 {
+		m.focus = false
+		gtx.Execute(key.FocusCmd{Tag: m.buttons[0]})
+	}
+
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			kit.PaintShadow(gtx, size, kit.Radius.XL, Elevation3)
+			defer clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(kit.Radius.XL)).Push(gtx.Ops).Pop()
+			event.Op(gtx.Ops, &m.dialog)
+			paint.Fill(gtx.Ops, kit.Colors.SurfaceElevated)
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.UniformInset(kit.Spacing.Large).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if d.Title == "" {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Bottom: kit.Spacing.Medium}.Layout(gtx,
+							kit.Label(d.Title, kit.Typography.HeadlineSmall, kit.Colors.OnSurfaceElevated).Layout)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if d.Body == "" {
+							return layout.Dimensions{}
+						}
+						return kit.Label(d.Body, kit.Typography.BodyMedium, kit.Colors.OnSurfaceElevated).Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if d.Content == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Top: kit.Spacing.Medium}.Layout(gtx, d.Content)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if len(d.Actions) == 0 {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Top: kit.Spacing.Large}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return kit.layoutDialogActions(gtx, m)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
+// layoutDialogActions lays out the action buttons right-aligned
+func (kit *UIKit) layoutDialogActions(gtx layout.Context, m *Modal) layout.Dimensions {
+	children := []layout.FlexChild{layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, 0)}
+	})}
+	for i, a := range m.dialog.Actions {
+		if i > 0 {
+			children = append(children, layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout))
+		}
+		btn := kit.ButtonStyle(m.buttons[i], a.Text, a.Variant, ButtonMedium)
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			// Buttons stay inert, but keep their colors, while the dialog animates out
+			if m.closing {
+				gtx = gtx.Disabled()
+			}
+			return btn.Layout(gtx)
+		}))
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
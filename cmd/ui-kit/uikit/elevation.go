@@ -0,0 +1,94 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// Elevation is how far a surface floats above the page. Higher surfaces
+// cast larger, softer shadows.
+type Elevation int
+
+const (
+	Elevation0 Elevation = iota // Flat, no shadow
+	Elevation1                  // Cards
+	Elevation2                  // Menus and hovered cards
+	Elevation3                  // Dialogs, toasts and FABs
+	Elevation4                  // Hovered FABs
+	Elevation5                  // Dragged items
+)
+
+// Bounds on the rectangles drawn per shadow. Even the smallest blur gets a
+// few, a pixel apart, so that it still fades out.
+const (
+	minShadowLayers = 3
+	maxShadowLayers = 8
+)
+
+// PaintShadow draws the shadow of a rounded rectangle of the given size
+// raised to elevation e. Paint the surface itself afterwards; the shadow
+// reaches past size on every side.
+func (kit *UIKit) PaintShadow(gtx layout.Context, size image.Point, radius unit.Dp, e Elevation) {
+	kit.paintShadow(gtx, size, gtx.Dp(radius), float32(e))
+}
+
+// paintShadow draws a soft ambient shadow and a key shadow cast downwards,
+// like light from above. The level may be fractional while an elevation
+// change animates.
+func (kit *UIKit) paintShadow(gtx layout.Context, size image.Point, radius int, level float32) {
+	if level <= 0 {
+		return
+	}
+	blur := gtx.Dp(kit.shadowBlur(level))
+	shadow := kit.Colors.Shadow
+	key := withAlpha(shadow, uint8(min(0xFF, 2*int(shadow.A))))
+
+	rect := image.Rectangle{Max: size}
+	softRRect(gtx.Ops, rect, radius, blur, shadow)
+	softRRect(gtx.Ops, rect.Add(image.Pt(0, blur/2)), radius, blur, key)
+}
+
+// shadowBlur returns the blur radius of an elevation level, interpolated
+// along the Shadows scale
+func (kit *UIKit) shadowBlur(level float32) unit.Dp {
+	s := kit.Shadows
+	stops := [...]unit.Dp{0, s.Small, s.Medium, s.Large, s.Large * 3 / 2, s.Large * 2}
+	last := float32(len(stops) - 1)
+	level = max(0, min(last, level))
+	i := int(level)
+	if float32(i) == last {
+		return stops[i]
+	}
+	f := unit.Dp(level - float32(i))
+	return stops[i] + (stops[i+1]-stops[i])*f
+}
+
+// softRRect approximates a blurred rounded rectangle with translucent
+// layers spreading from blur/2 inside its edge to blur/2 outside. Where they
+// overlap the alpha builds up to col's, giving a smooth falloff. Blurs too
+// small for minShadowLayers distinct layers are widened until they fit.
+func softRRect(ops *op.Ops, r image.Rectangle, radius, blur int, col color.NRGBA) {
+	if col.A == 0 {
+		return
+	}
+	spread := max(blur, minShadowLayers-1)
+	n := max(minShadowLayers, min(maxShadowLayers, spread/2))
+	a := 1 - math.Pow(1-float64(col.A)/0xFF, 1/float64(n))
+	layer := withAlpha(col, uint8(math.Round(a*0xFF)))
+	for i := 0; i < n; i++ {
+		d := int(math.Round(float64(spread) * (float64(2*i+1)/float64(2*n) - 0.5)))
+		rr := r.Inset(-d)
+		if rr.Empty() {
+			continue
+		}
+		rad := max(0, min(radius+d, rr.Dx()/2, rr.Dy()/2))
+		paint.FillShape(ops, layer, clip.UniformRRect(rr, rad).Op(ops))
+	}
+}
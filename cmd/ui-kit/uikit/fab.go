@@ -0,0 +1,118 @@
+package uikit
+
+import (
+	"image"
+
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// FAB sizes
+const (
+	fabSize      = unit.Dp(56)
+	fabSmallSize = unit.Dp(40)
+	fabIconSize  = unit.Dp(24)
+)
+
+// FABStyle describes a floating action button, the raised button for the
+// main action of a screen. It rests at Elevation3 and lifts to Elevation4
+// while hovered or focused.
+type FABStyle struct {
+	Icon *Icon
+	// Text extends the button with a label after the icon
+	Text string
+	// Description is announced by screen readers when there is no Text
+	Description string
+	// Small draws a 40dp button instead of 56dp
+	Small  bool
+	Button *widget.Clickable
+
+	kit *UIKit
+}
+
+// FAB creates a floating action button; description labels it for screen readers
+func (kit *UIKit) FAB(btn *widget.Clickable, icon *Icon, description string) layout.Widget {
+	f := kit.FABStyle(btn, icon)
+	f.Description = description
+	return f.Layout
+}
+
+// FABStyle returns a floating action button that can be further configured before layout
+func (kit *UIKit) FABStyle(btn *widget.Clickable, icon *Icon) FABStyle {
+	return FABStyle{
+		Icon:   icon,
+		Button: btn,
+		kit:    kit,
+	}
+}
+
+// Layout draws the button
+func (f FABStyle) Layout(gtx layout.Context) layout.Dimensions {
+	return f.Button.Layout(gtx, f.layout)
+}
+
+func (f FABStyle) layout(gtx layout.Context) layout.Dimensions {
+	kit := f.kit
+	semantic.Button.Add(gtx.Ops)
+	if f.Description != "" {
+		semantic.DescriptionOp(f.Description).Add(gtx.Ops)
+	}
+
+	size, radius := fabSize, kit.Radius.XL
+	if f.Small {
+		size, radius = fabSmallSize, kit.Radius.Large
+	}
+	focused := gtx.Focused(f.Button)
+
+	// Ease between rest (0), hover or focus (1) and pressed (2), like
+	// buttons; pressing settles the FAB back to its resting elevation
+	var level float32
+	switch {
+	case f.Button.Pressed():
+		level = 2
+	case f.Button.Hovered(), focused:
+		level = 1
+	}
+	tw := kit.tween(gtx, f.Button)
+	tw.Duration = kit.Motion.Duration(kit.Motion.Fast)
+	tw.To(level)
+	level = tw.Value(gtx)
+	elevation := float32(Elevation3) + min(level, 2-level)
+	bg := mixStops(level, kit.Colors.Primary500, kit.Colors.Primary600, kit.Colors.Primary700)
+	fg := kit.Colors.OnPrimary
+
+	return layout.Stack{Alignment: layout.Center}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			sz := gtx.Constraints.Min
+			rr := gtx.Dp(radius)
+			kit.paintShadow(gtx, sz, rr, elevation)
+			paint.FillShape(gtx.Ops, bg, clip.UniformRRect(image.Rectangle{Max: sz}, rr).Op(gtx.Ops))
+			if focused {
+				kit.strokeRRect(gtx, sz, radius, unit.Dp(2), kit.Colors.Focus)
+			}
+			return layout.Dimensions{Size: sz}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			side := gtx.Dp(size)
+			gtx.Constraints.Min = image.Pt(side, side)
+			icon := func(gtx layout.Context) layout.Dimensions {
+				return layoutIcon(gtx, f.Icon, gtx.Dp(fabIconSize), fg)
+			}
+			if f.Text == "" {
+				return layout.Center.Layout(gtx, icon)
+			}
+			return layout.Inset{Left: kit.Spacing.Medium, Right: kit.Spacing.Medium + kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = 0
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(icon),
+					layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout),
+					layout.Rigid(kit.Label(f.Text, kit.Typography.LabelLarge, fg).Layout),
+				)
+			})
+		}),
+	)
+}
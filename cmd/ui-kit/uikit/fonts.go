@@ -0,0 +1,174 @@
+package uikit
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/font/gofont"
+	"gioui.org/font/opentype"
+	"gioui.org/text"
+)
+
+// FontRole groups the typography styles that share a typeface
+type FontRole int
+
+const (
+	FontDisplay FontRole = iota // Display and Headline styles
+	FontBody                    // Title, Body and Label styles
+	FontMono                    // The Mono style
+)
+
+// Common fallback families for scripts the Go fonts do not cover. They are
+// resolved against registered fonts first and installed system fonts second.
+const (
+	FallbackCJK    font.Typeface = "Noto Sans CJK SC, Noto Sans CJK JP, Noto Sans CJK KR, PingFang SC, Microsoft YaHei"
+	FallbackArabic font.Typeface = "Noto Sans Arabic, Noto Naskh Arabic, Geeza Pro, Segoe UI"
+	FallbackEmoji  font.Typeface = "Noto Color Emoji, Apple Color Emoji, Segoe UI Emoji, emoji"
+)
+
+// defaultRoleFaces are the families used for roles without a SetFontRole typeface
+var defaultRoleFaces = [3]font.Typeface{"Go", "Go", "Go Mono, monospace"}
+
+// fontState tracks registered faces and the typeface chosen for each role
+type fontState struct {
+	faces     []font.FontFace
+	roles     [3]font.Typeface
+	fallbacks []font.Typeface
+	// applied is the chain last written to each role's styles, so that
+	// typefaces set some other way, such as by a token file, are left alone
+	applied [3]font.Typeface
+}
+
+// RegisterFont parses a TTF, OTF or font collection and makes its faces
+// available to every kit component. It returns the family names found, for
+// use with SetFontRole. The text shaper is rebuilt.
+func (kit *UIKit) RegisterFont(data []byte) ([]font.Typeface, error) {
+	faces, err := opentype.ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	kit.fonts.faces = append(kit.fonts.faces, faces...)
+	kit.rebuildShaper()
+	return familyNames(faces), nil
+}
+
+// RegisterFontFile registers the font file at path
+func (kit *UIKit) RegisterFontFile(path string) ([]font.Typeface, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	families, err := kit.RegisterFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return families, nil
+}
+
+// RegisterFontFS registers every font in fsys matching pattern, such as
+// "fonts/*.ttf" in an embed.FS, rebuilding the shaper once at the end
+func (kit *UIKit) RegisterFontFS(fsys fs.FS, pattern string) ([]font.Typeface, error) {
+	paths, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no fonts match %q", pattern)
+	}
+
+	var faces []font.FontFace
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		faces = append(faces, parsed...)
+	}
+	kit.fonts.faces = append(kit.fonts.faces, faces...)
+	kit.rebuildShaper()
+	return familyNames(faces), nil
+}
+
+// SetFontRole assigns a typeface to every typography style in role that
+// has no typeface of its own. The fallbacks from SetFallbackFonts are
+// appended, so characters the typeface lacks are drawn from the first
+// fallback that covers them.
+func (kit *UIKit) SetFontRole(role FontRole, typeface font.Typeface) {
+	kit.fonts.roles[role] = typeface
+	kit.applyFontRole(role)
+}
+
+// SetFallbackFonts sets the per-script fallback chain tried after each
+// role's typeface, e.g. SetFallbackFonts(FallbackCJK, FallbackArabic, FallbackEmoji)
+func (kit *UIKit) SetFallbackFonts(typefaces ...font.Typeface) {
+	kit.fonts.fallbacks = typefaces
+	for role := range kit.fonts.roles {
+		kit.applyFontRole(FontRole(role))
+	}
+}
+
+func (kit *UIKit) applyFontRole(role FontRole) {
+	typeface := kit.fonts.roles[role]
+	if typeface == "" {
+		typeface = defaultRoleFaces[role]
+	}
+	chain := []string{string(typeface)}
+	for _, f := range kit.fonts.fallbacks {
+		chain = append(chain, string(f))
+	}
+	typeface = font.Typeface(strings.Join(chain, ", "))
+
+	t := &kit.Typography
+	var styles []*TypographyStyle
+	switch role {
+	case FontDisplay:
+		styles = []*TypographyStyle{
+			&t.DisplayLarge, &t.DisplayMedium, &t.DisplaySmall,
+			&t.HeadlineLarge, &t.HeadlineMedium, &t.HeadlineSmall,
+		}
+	case FontBody:
+		styles = []*TypographyStyle{
+			&t.TitleLarge, &t.TitleMedium, &t.TitleSmall,
+			&t.BodyLarge, &t.BodyMedium, &t.BodySmall,
+			&t.LabelLarge, &t.LabelMedium, &t.LabelSmall,
+		}
+		kit.Theme.Face = typeface
+	case FontMono:
+		styles = []*TypographyStyle{&t.Mono}
+	}
+	for _, s := range styles {
+		switch s.Typeface {
+		case "", defaultRoleFaces[role], kit.fonts.applied[role]:
+			s.Typeface = typeface
+		}
+	}
+	kit.fonts.applied[role] = typeface
+}
+
+// rebuildShaper replaces the theme's shaper with one holding the Go fonts
+// followed by every registered face. System fonts stay enabled as the last
+// resort for scripts nothing registered covers.
+func (kit *UIKit) rebuildShaper() {
+	collection := append([]font.FontFace{}, gofont.Collection()...)
+	collection = append(collection, kit.fonts.faces...)
+	kit.Theme.Shaper = text.NewShaper(text.WithCollection(collection))
+}
+
+func familyNames(faces []font.FontFace) []font.Typeface {
+	var names []font.Typeface
+	seen := make(map[font.Typeface]bool)
+	for _, f := range faces {
+		if !seen[f.Font.Typeface] {
+			seen[f.Font.Typeface] = true
+			names = append(names, f.Font.Typeface)
+		}
+	}
+	return names
+}
@@ -0,0 +1,34 @@
+package uikit
+
+import (
+	"testing"
+
+	"gioui.org/font"
+)
+
+func TestFontRoles(t *testing.T) {
+	kit := NewUIKit()
+	kit.Typography.TitleLarge.Typeface = "Brand" // As a token file would
+	kit.SetFontRole(FontBody, "Inter")
+
+	check := func(name string, got, want font.Typeface) {
+		t.Helper()
+		if got != want {
+			t.Errorf("%s typeface = %q; want %q", name, got, want)
+		}
+	}
+	check("BodyMedium", kit.Typography.BodyMedium.Typeface, "Inter")
+	check("theme", kit.Theme.Face, "Inter")
+	check("TitleLarge", kit.Typography.TitleLarge.Typeface, "Brand")
+	check("DisplayLarge", kit.Typography.DisplayLarge.Typeface, "")
+
+	kit.SetFallbackFonts(FallbackEmoji)
+	check("BodyMedium", kit.Typography.BodyMedium.Typeface, "Inter, "+FallbackEmoji)
+	check("DisplayLarge", kit.Typography.DisplayLarge.Typeface, "Go, "+FallbackEmoji)
+	check("Mono", kit.Typography.Mono.Typeface, "Go Mono, monospace, "+FallbackEmoji)
+	check("TitleLarge", kit.Typography.TitleLarge.Typeface, "Brand")
+
+	kit.SetFontRole(FontBody, "Roboto")
+	check("BodyMedium", kit.Typography.BodyMedium.Typeface, "Roboto, "+FallbackEmoji)
+	check("TitleLarge", kit.Typography.TitleLarge.Typeface, "Brand")
+}
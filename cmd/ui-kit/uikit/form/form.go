@@ -0,0 +1,295 @@
+// Package form validates user input. A Form holds Fields, each checked by
+// a chain of Validators and optionally an asynchronous validator, and
+// decides when their errors are shown according to its Mode.
+package form
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// Mode decides when a field's errors are first shown. Once shown, they
+// update on every change until the field is reset.
+type Mode int
+
+const (
+	// OnBlur shows errors when the field loses focus
+	OnBlur Mode = iota
+	// OnChange shows errors as soon as the value is edited
+	OnChange
+	// OnSubmit shows errors only when the form is submitted
+	OnSubmit
+)
+
+// DefaultAsyncDelay is how long typing must pause before an asynchronous
+// validator runs, when AsyncDelay is zero
+const DefaultAsyncDelay = 300 * time.Millisecond
+
+// AsyncValidator checks a value off the UI goroutine, e.g. against a
+// server. The context is cancelled when the value changes again.
+type AsyncValidator func(ctx context.Context, value string) error
+
+// Form tracks the fields of one form. Update it once per frame, before
+// laying out its inputs.
+type Form struct {
+	Mode Mode
+	// Invalidate is called when an asynchronous validator finishes, to wake
+	// the UI; usually set to the app window's Invalidate method
+	Invalidate func()
+
+	mu        sync.Mutex // Guards the async state of the fields
+	fields    []*Field
+	submitted bool
+}
+
+// Field is one validated value of a form
+type Field struct {
+	Name       string
+	Validators []Validator
+	// Async runs after the Validators pass
+	Async      AsyncValidator
+	AsyncDelay time.Duration
+
+	form  *Form
+	value func() string
+	focus any
+
+	last    string
+	checked bool
+	focused bool
+	shown   bool
+	err     error
+
+	asyncValue string
+	asyncErr   error
+	asyncDone  bool
+	cancel     context.CancelFunc
+}
+
+// Field adds a field whose value is read with value. focus is the tag
+// that holds the keyboard focus for the field, such as its widget.Editor,
+// and may be nil for fields that never lose focus.
+func (f *Form) Field(name string, value func() string, focus any, validators ...Validator) *Field {
+	fld := &Field{
+		Name:       name,
+		Validators: validators,
+		form:       f,
+		value:      value,
+		focus:      focus,
+	}
+	f.fields = append(f.fields, fld)
+	return fld
+}
+
+// Editor adds a field for the text of ed
+func (f *Form) Editor(name string, ed *widget.Editor, validators ...Validator) *Field {
+	return f.Field(name, ed.Text, ed, validators...)
+}
+
+// Fields returns the fields in the order they were added
+func (f *Form) Fields() []*Field {
+	return f.fields
+}
+
+// Lookup returns the field with the given name, or nil
+func (f *Form) Lookup(name string) *Field {
+	for _, fld := range f.fields {
+		if fld.Name == name {
+			return fld
+		}
+	}
+	return nil
+}
+
+// Update checks changed fields and shows errors as the Mode dictates
+func (f *Form) Update(gtx layout.Context) {
+	for _, fld := range f.fields {
+		focused := fld.focus != nil && gtx.Focused(fld.focus)
+		blurred := fld.focused && !focused
+		fld.focused = focused
+
+		first := !fld.checked
+		changed := fld.check()
+		switch {
+		case blurred && f.Mode != OnSubmit:
+			fld.shown = true
+		case changed && !first && f.Mode == OnChange:
+			fld.shown = true
+		}
+	}
+}
+
+// Valid reports whether every field passes its validators, including any
+// asynchronous ones. It is false while asynchronous validation is running,
+// so it can be used to disable a submit button.
+func (f *Form) Valid() bool {
+	for _, fld := range f.fields {
+		if !fld.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Validating reports whether any asynchronous validator is still running
+func (f *Form) Validating() bool {
+	for _, fld := range f.fields {
+		if fld.Validating() {
+			return true
+		}
+	}
+	return false
+}
+
+// Submit shows the errors of every field and reports whether the form is
+// valid. If it is not, the first invalid field takes the keyboard focus.
+func (f *Form) Submit(gtx layout.Context) bool {
+	f.submitted = true
+	var invalid *Field
+	for _, fld := range f.fields {
+		fld.check()
+		fld.shown = true
+		if invalid == nil && !fld.Valid() {
+			invalid = fld
+		}
+	}
+	if invalid != nil && invalid.focus != nil {
+		gtx.Execute(key.FocusCmd{Tag: invalid.focus})
+	}
+	return invalid == nil
+}
+
+// Submitted reports whether Submit has been called since the last Reset
+func (f *Form) Submitted() bool {
+	return f.submitted
+}
+
+// Reset hides every error, for example after clearing the form
+func (f *Form) Reset() {
+	f.submitted = false
+	for _, fld := range f.fields {
+		fld.Reset()
+	}
+}
+
+// Error returns the message to show for the field, or "" while it is
+// valid or its errors are not shown yet
+func (fld *Field) Error() string {
+	if !fld.shown {
+		return ""
+	}
+	fld.check()
+	if fld.err != nil {
+		return fld.err.Error()
+	}
+	fld.form.mu.Lock()
+	defer fld.form.mu.Unlock()
+	if fld.asyncDone && fld.asyncErr != nil {
+		return fld.asyncErr.Error()
+	}
+	return ""
+}
+
+// Valid reports whether the field passes its validators, whether or not
+// its errors are shown
+func (fld *Field) Valid() bool {
+	fld.check()
+	if fld.err != nil {
+		return false
+	}
+	if fld.Async == nil {
+		return true
+	}
+	fld.form.mu.Lock()
+	defer fld.form.mu.Unlock()
+	return fld.asyncDone && fld.asyncErr == nil
+}
+
+// Validating reports whether the field's asynchronous validator is running
+func (fld *Field) Validating() bool {
+	fld.check()
+	if fld.Async == nil || fld.err != nil {
+		return false
+	}
+	fld.form.mu.Lock()
+	defer fld.form.mu.Unlock()
+	return !fld.asyncDone
+}
+
+// Validate shows the field's errors now, regardless of the form's Mode
+func (fld *Field) Validate() {
+	fld.check()
+	fld.shown = true
+}
+
+// Reset hides the field's errors until the Mode shows them again
+func (fld *Field) Reset() {
+	fld.shown = false
+	fld.checked = false
+}
+
+// check runs the validators if the value changed since the last check,
+// and reports whether it did
+func (fld *Field) check() bool {
+	v := fld.value()
+	if fld.checked && v == fld.last {
+		return false
+	}
+	fld.last, fld.checked = v, true
+	fld.err = All(fld.Validators...)(v)
+	if fld.Async != nil {
+		fld.startAsync(v)
+	}
+	return true
+}
+
+// startAsync cancels any running asynchronous check and starts one for v,
+// if the synchronous validators passed
+func (fld *Field) startAsync(v string) {
+	f := fld.form
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if fld.cancel != nil {
+		fld.cancel()
+		fld.cancel = nil
+	}
+	fld.asyncValue, fld.asyncErr = v, nil
+	fld.asyncDone = fld.err != nil
+	if fld.asyncDone {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fld.cancel = cancel
+	delay := fld.AsyncDelay
+	if delay == 0 {
+		delay = DefaultAsyncDelay
+	}
+	validate := fld.Async
+	go func() {
+		defer cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		err := validate(ctx, v)
+
+		f.mu.Lock()
+		if ctx.Err() != nil {
+			f.mu.Unlock()
+			return
+		}
+		fld.asyncErr, fld.asyncDone = err, true
+		invalidate := f.Invalidate
+		f.mu.Unlock()
+		if invalidate != nil {
+			invalidate()
+		}
+	}()
+}
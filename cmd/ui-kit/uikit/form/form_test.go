@@ -0,0 +1,110 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     Validator
+		value string
+		ok    bool
+	}{
+		{"required empty", Required(), "", false},
+		{"required blank", Required(), "  ", false},
+		{"required", Required(), "x", true},
+		{"email", Email(), "jane@example.com", true},
+		{"email without domain", Email(), "jane@", false},
+		{"email empty", Email(), "", true},
+		{"min length", MinLength(3), "ab", false},
+		{"min length counts runes", MinLength(3), "äöü", true},
+		{"max length", MaxLength(3), "abcd", false},
+		{"pattern", Pattern(regexp.MustCompile(`^\d+$`), "digits"), "12a", false},
+		{"range", Range(1, 10), "10", true},
+		{"range below", Range(1, 10), "0.5", false},
+		{"range not a number", Range(1, 10), "ten", false},
+		{"func", Func(func(s string) bool { return s != "no" }, "not no"), "no", false},
+		{"all", All(Required(), MinLength(2)), "a", false},
+	}
+	for _, tt := range tests {
+		if err := tt.v(tt.value); (err == nil) != tt.ok {
+			t.Errorf("%s: %q gave %v, want ok %v", tt.name, tt.value, err, tt.ok)
+		}
+	}
+	if err := WithMessage(Required(), "Name needed")(""); err == nil || err.Error() != "Name needed" {
+		t.Errorf("WithMessage gave %v", err)
+	}
+}
+
+func TestModes(t *testing.T) {
+	var gtx layout.Context
+	value := ""
+	for _, mode := range []Mode{OnChange, OnBlur, OnSubmit} {
+		f := Form{Mode: mode}
+		fld := f.Field("name", func() string { return value }, nil, Required(), MinLength(3))
+		value = ""
+		f.Update(gtx)
+		if fld.Error() != "" {
+			t.Errorf("mode %d: error shown before any change", mode)
+		}
+		value = "ab"
+		f.Update(gtx)
+		if got, want := fld.Error() != "", mode == OnChange; got != want {
+			t.Errorf("mode %d: error shown after change = %v, want %v", mode, got, want)
+		}
+		if f.Valid() {
+			t.Errorf("mode %d: invalid form reported valid", mode)
+		}
+		if f.Submit(gtx) || fld.Error() == "" {
+			t.Errorf("mode %d: submit passed or showed no error", mode)
+		}
+		value = "abc"
+		if fld.Error() != "" || !f.Valid() {
+			t.Errorf("mode %d: fixed field still fails: %q", mode, fld.Error())
+		}
+		f.Reset()
+		value = ""
+		if fld.Error() != "" {
+			t.Errorf("mode %d: error shown after reset", mode)
+		}
+	}
+}
+
+func TestAsync(t *testing.T) {
+	done := make(chan struct{}, 1)
+	f := Form{Invalidate: func() { done <- struct{}{} }}
+	value := "taken"
+	fld := f.Field("user", func() string { return value }, nil, Required())
+	fld.AsyncDelay = time.Millisecond
+	fld.Async = func(ctx context.Context, v string) error {
+		if v == "taken" {
+			return errors.New("Name taken")
+		}
+		return nil
+	}
+
+	if f.Valid() || !f.Validating() {
+		t.Fatal("form should be validating before the async check finishes")
+	}
+	<-done
+	fld.Validate()
+	if f.Valid() || fld.Error() != "Name taken" {
+		t.Errorf("async error not reported: valid %v, error %q", f.Valid(), fld.Error())
+	}
+
+	value = "free"
+	if f.Valid() {
+		t.Error("form valid before the new value was checked")
+	}
+	<-done
+	if !f.Valid() || fld.Error() != "" {
+		t.Errorf("free value rejected: %q", fld.Error())
+	}
+}
@@ -0,0 +1,113 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks a value and returns an error whose message is shown to
+// the user. Every validator but Required accepts the empty string, so
+// optional fields can be checked only when filled in.
+type Validator func(value string) error
+
+// emailPattern is deliberately loose: something, an @, and a dotted domain
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// Required rejects empty and blank values
+func Required() Validator {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New("This field is required")
+		}
+		return nil
+	}
+}
+
+// Email accepts values shaped like an email address
+func Email() Validator {
+	return Pattern(emailPattern, "Enter a valid email address")
+}
+
+// MinLength rejects values shorter than n characters
+func MinLength(n int) Validator {
+	return func(value string) error {
+		if value != "" && utf8.RuneCountInString(value) < n {
+			return fmt.Errorf("Enter at least %d characters", n)
+		}
+		return nil
+	}
+}
+
+// MaxLength rejects values longer than n characters
+func MaxLength(n int) Validator {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf("Enter no more than %d characters", n)
+		}
+		return nil
+	}
+}
+
+// Pattern accepts values matching re
+func Pattern(re *regexp.Regexp, message string) Validator {
+	return func(value string) error {
+		if value != "" && !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Range accepts numbers from min to max inclusive
+func Range(min, max float64) Validator {
+	return func(value string) error {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("Enter a number")
+		}
+		if n < min || n > max {
+			return fmt.Errorf("Enter a number from %g to %g", min, max)
+		}
+		return nil
+	}
+}
+
+// Func turns a predicate into a validator that fails with message
+func Func(ok func(value string) bool, message string) Validator {
+	return func(value string) error {
+		if !ok(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// WithMessage replaces the message of v's errors
+func WithMessage(v Validator, message string) Validator {
+	return func(value string) error {
+		if v(value) != nil {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// All runs validators in order and returns the first error
+func All(validators ...Validator) Validator {
+	return func(value string) error {
+		for _, v := range validators {
+			if err := v(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
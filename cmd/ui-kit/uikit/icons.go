@@ -0,0 +1,187 @@
+package uikit
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+)
+
+// Names of the icons every registry starts with
+const (
+	IconAdd           = "add"
+	IconArrowBack     = "arrow_back"
+	IconArrowForward  = "arrow_forward"
+	IconCalendar      = "calendar"
+	IconCheck         = "check"
+	IconCheckCircle   = "check_circle"
+	IconChevronLeft   = "chevron_left"
+	IconChevronRight  = "chevron_right"
+	IconClear         = "clear"
+	IconClose         = "close"
+	IconCopy          = "copy"
+	IconDelete        = "delete"
+	IconDone          = "done"
+	IconEdit          = "edit"
+	IconError         = "error"
+	IconExpandLess    = "expand_less"
+	IconExpandMore    = "expand_more"
+	IconFavorite      = "favorite"
+	IconHelp          = "help"
+	IconHome          = "home"
+	IconInfo          = "info"
+	IconLock          = "lock"
+	IconMail          = "mail"
+	IconMenu          = "menu"
+	IconMoreVert      = "more_vert"
+	IconPerson        = "person"
+	IconRefresh       = "refresh"
+	IconRemove        = "remove"
+	IconSave          = "save"
+	IconSearch        = "search"
+	IconSend          = "send"
+	IconSettings      = "settings"
+	IconStar          = "star"
+	IconUndo          = "undo"
+	IconVisibility    = "visibility"
+	IconVisibilityOff = "visibility_off"
+	IconWarning       = "warning"
+)
+
+var builtinIcons = map[string][]byte{
+	IconAdd:           icons.ContentAdd,
+	IconArrowBack:     icons.NavigationArrowBack,
+	IconArrowForward:  icons.NavigationArrowForward,
+	IconCalendar:      icons.ActionDateRange,
+	IconCheck:         icons.NavigationCheck,
+	IconCheckCircle:   icons.ActionCheckCircle,
+	IconChevronLeft:   icons.NavigationChevronLeft,
+	IconChevronRight:  icons.NavigationChevronRight,
+	IconClear:         icons.NavigationCancel,
+	IconClose:         icons.NavigationClose,
+	IconCopy:          icons.ContentContentCopy,
+	IconDelete:        icons.ActionDelete,
+	IconDone:          icons.ActionDone,
+	IconEdit:          icons.EditorModeEdit,
+	IconError:         icons.AlertError,
+	IconExpandLess:    icons.NavigationExpandLess,
+	IconExpandMore:    icons.NavigationExpandMore,
+	IconFavorite:      icons.ActionFavorite,
+	IconHelp:          icons.ActionHelp,
+	IconHome:          icons.ActionHome,
+	IconInfo:          icons.ActionInfo,
+	IconLock:          icons.ActionLock,
+	IconMail:          icons.ContentMail,
+	IconMenu:          icons.NavigationMenu,
+	IconMoreVert:      icons.NavigationMoreVert,
+	IconPerson:        icons.SocialPerson,
+	IconRefresh:       icons.NavigationRefresh,
+	IconRemove:        icons.ContentRemove,
+	IconSave:          icons.ContentSave,
+	IconSearch:        icons.ActionSearch,
+	IconSend:          icons.ContentSend,
+	IconSettings:      icons.ActionSettings,
+	IconStar:          icons.ToggleStar,
+	IconUndo:          icons.ContentUndo,
+	IconVisibility:    icons.ActionVisibility,
+	IconVisibilityOff: icons.ActionVisibilityOff,
+	IconWarning:       icons.AlertWarning,
+}
+
+// Icon is an IconVG vector icon that can be drawn at any size and color.
+// widget.Icon caches one rasterization; Icon keeps one per color so the
+// same icon can appear in several states in a frame without redrawing.
+type Icon struct {
+	src      []byte
+	variants map[color.NRGBA]*widget.Icon
+}
+
+// maxIconVariants bounds the per-color cache, which would otherwise grow
+// without limit while a color is being animated
+const maxIconVariants = 8
+
+// NewIcon parses IconVG data, such as the icons in
+// golang.org/x/exp/shiny/materialdesign/icons
+func NewIcon(data []byte) (*Icon, error) {
+	if _, err := widget.NewIcon(data); err != nil {
+		return nil, err
+	}
+	return &Icon{src: data}, nil
+}
+
+// Layout draws the icon as a square of gtx.Constraints.Min.X pixels
+func (ic *Icon) Layout(gtx layout.Context, col color.NRGBA) layout.Dimensions {
+	w, ok := ic.variants[col]
+	if !ok {
+		if ic.variants == nil || len(ic.variants) >= maxIconVariants {
+			ic.variants = make(map[color.NRGBA]*widget.Icon)
+		}
+		// The data was validated by NewIcon
+		w, _ = widget.NewIcon(ic.src)
+		ic.variants[col] = w
+	}
+	return w.Layout(gtx, col)
+}
+
+// IconRegistry maps names to icons
+type IconRegistry struct {
+	icons map[string]*Icon
+}
+
+// NewIconRegistry returns a registry holding the built-in Icon* names
+func NewIconRegistry() *IconRegistry {
+	r := &IconRegistry{icons: make(map[string]*Icon, len(builtinIcons))}
+	for name, data := range builtinIcons {
+		if err := r.Register(name, data); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register adds or replaces the icon stored under name
+func (r *IconRegistry) Register(name string, data []byte) error {
+	ic, err := NewIcon(data)
+	if err != nil {
+		return fmt.Errorf("icon %q: %w", name, err)
+	}
+	r.icons[name] = ic
+	return nil
+}
+
+// Get returns the icon registered under name, or nil
+func (r *IconRegistry) Get(name string) *Icon {
+	return r.icons[name]
+}
+
+// Names returns the registered icon names in sorted order
+func (r *IconRegistry) Names() []string {
+	names := make([]string, 0, len(r.icons))
+	for name := range r.icons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Icon draws the named icon at the given size. Unknown names draw nothing
+// but still take up the space, so layouts do not shift.
+func (kit *UIKit) Icon(name string, size unit.Dp, col color.NRGBA) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		return layoutIcon(gtx, kit.Icons.Get(name), gtx.Dp(size), col)
+	}
+}
+
+// layoutIcon draws ic as a size×size pixel square; a nil icon leaves it empty
+func layoutIcon(gtx layout.Context, ic *Icon, size int, col color.NRGBA) layout.Dimensions {
+	gtx.Constraints = layout.Exact(image.Pt(size, size))
+	if ic == nil {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	return ic.Layout(gtx, col)
+}
@@ -0,0 +1,445 @@
+package uikit
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// Input looks
+type InputVariant int
+
+const (
+	// InputOutlined is a bordered box on the surface
+	InputOutlined InputVariant = iota
+	// InputFilled is a tinted box with a line along the bottom
+	InputFilled
+)
+
+// Where an input's Label is drawn
+type InputLabelPlacement int
+
+const (
+	// InputLabelAbove draws the label over the field, outside it
+	InputLabelAbove InputLabelPlacement = iota
+	// InputLabelFloating draws the label in place of the text while the
+	// field is empty and unfocused, and moves it to the top edge otherwise
+	InputLabelFloating
+)
+
+// InputStyle describes a single line text input. The widget.Editor holds the
+// text and focus; everything else is set per frame.
+type InputStyle struct {
+	Variant InputVariant
+	Label   string
+	// LabelPlacement decides where the Label goes
+	LabelPlacement InputLabelPlacement
+	Hint           string
+	HasError       bool
+	// Error is shown under the input, and implies HasError
+	Error string
+	// Helper is shown under the input while there is no Error
+	Helper string
+	// MaxLength limits the text to that many characters and shows a counter
+	// under the input; zero means no limit
+	MaxLength int
+	// Icons drawn inside the field before and after the text
+	PrefixIcon *Icon
+	SuffixIcon *Icon
+	// Text drawn next to the text, such as a currency or a domain. Under a
+	// floating label they only appear once the label has moved up.
+	Prefix string
+	Suffix string
+	// Clear shows a button that empties the field while it has text
+	Clear *widget.Clickable
+	// Trailing is drawn at the very end of the field, for controls such as
+	// a visibility toggle
+	Trailing layout.Widget
+	// ReadOnly text can be selected and copied but not edited
+	ReadOnly bool
+	// Disabled dims the input and stops it from taking focus
+	Disabled bool
+	Editor   *widget.Editor
+
+	// Set by components built on the input
+	minLines, maxLines int           // Bounds on the editor's height, in lines
+	height             int           // Fixed field height in pixels, or zero
+	overlay            layout.Widget // Drawn over the field at its size
+	// Fields without an Editor draw content in its place, take focus as
+	// focus and float their label while filled
+	content layout.Widget
+	focus   event.Tag
+	filled  bool
+
+	kit *UIKit
+}
+
+// Input field with consistent styling
+func (kit *UIKit) Input(editor *widget.Editor, hint string, hasError bool) layout.Widget {
+	in := kit.InputStyle(editor, hint)
+	in.HasError = hasError
+	return in.Layout
+}
+
+// InputField is an outlined input with a floating label
+func (kit *UIKit) InputField(editor *widget.Editor, label, hint string) layout.Widget {
+	in := kit.InputStyle(editor, hint)
+	in.Label = label
+	in.LabelPlacement = InputLabelFloating
+	return in.Layout
+}
+
+// InputStyle returns an input that can be further configured before layout
+func (kit *UIKit) InputStyle(editor *widget.Editor, hint string) InputStyle {
+	return InputStyle{
+		Hint:   hint,
+		Editor: editor,
+		kit:    kit,
+	}
+}
+
+// Layout draws the input with its label, and its error or helper text and
+// counter below. The style owns the editor's ReadOnly and MaxLen settings.
+func (in InputStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := in.kit
+	in.HasError = (in.HasError || in.Error != "") && !in.Disabled
+	if in.Editor != nil {
+		in.Editor.ReadOnly = in.ReadOnly || in.Disabled
+		in.Editor.MaxLen = in.MaxLength
+	}
+	if in.Disabled {
+		gtx = gtx.Disabled()
+	}
+	if in.Clear != nil && in.Editor != nil && in.Clear.Clicked(gtx) {
+		in.Editor.SetText("")
+		gtx.Execute(key.FocusCmd{Tag: in.Editor})
+	}
+
+	minY := gtx.Constraints.Min.Y
+	var children []layout.FlexChild
+	if in.Label != "" && in.LabelPlacement == InputLabelAbove {
+		col := kit.Colors.TextPrimary
+		if in.Disabled {
+			col = kit.Colors.TextDisabled
+		}
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: kit.Spacing.Tiny}.Layout(gtx,
+				kit.Label(in.Label, kit.Typography.LabelMedium, col).Layout)
+		}))
+	}
+	children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+		gtx.Constraints.Min.Y = minY
+		return in.layoutField(gtx)
+	}))
+	if in.Error != "" || in.Helper != "" || in.MaxLength > 0 {
+		children = append(children, layout.Rigid(in.layoutSupporting))
+	}
+	gtx.Constraints.Min.Y = 0
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// layoutSupporting lays out the error or helper text and the counter
+func (in InputStyle) layoutSupporting(gtx layout.Context) layout.Dimensions {
+	kit := in.kit
+	typo := kit.Typography.LabelSmall
+	text, col := in.Helper, kit.Colors.TextSecondary
+	if in.Error != "" && !in.Disabled {
+		text, col = in.Error, kit.Colors.Error
+	}
+	return layout.Inset{Top: kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return kit.Label(text, typo, col).Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if in.MaxLength <= 0 || in.Editor == nil {
+					return layout.Dimensions{}
+				}
+				counter := fmt.Sprintf("%d/%d", in.Editor.Len(), in.MaxLength)
+				return layout.Inset{Left: kit.Spacing.Small}.Layout(gtx,
+					kit.Label(counter, typo, kit.Colors.TextSecondary).Layout)
+			}),
+		)
+	})
+}
+
+// layoutField draws the field itself, with a floating label over it
+func (in InputStyle) layoutField(gtx layout.Context) layout.Dimensions {
+	kit := in.kit
+	if in.height > 0 {
+		gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = in.height, in.height
+	}
+	focused := gtx.Focused(in.tag())
+	bg, line := in.colors(focused)
+	lineWidth := unit.Dp(1)
+	if focused {
+		lineWidth = 2
+	}
+
+	// The floating label eases between resting in place of the text (0)
+	// and sitting on the top edge (1)
+	floating := in.Label != "" && in.LabelPlacement == InputLabelFloating
+	var float float32 = 1
+	if floating {
+		var target float32
+		if focused || in.isFilled() {
+			target = 1
+		}
+		tw := kit.tween(gtx, in.tag())
+		tw.Duration = kit.Motion.Duration(kit.Motion.Fast)
+		tw.To(target)
+		float = tw.Value(gtx)
+	}
+
+	inset := layout.UniformInset(kit.Spacing.Medium)
+	if floating && in.Variant == InputFilled {
+		inset.Top = kit.Spacing.Small + unit.Dp(kit.Typography.LabelSmall.LineHeight)
+		inset.Bottom = kit.Spacing.Small
+	}
+	// The hint and affixes would collide with a resting label
+	showAffixes := !floating || focused || in.isFilled()
+
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			rr := gtx.Dp(kit.Radius.Medium)
+			if in.Variant == InputFilled {
+				shape := clip.RRect{Rect: image.Rectangle{Max: size}, NW: rr, NE: rr}
+				paint.FillShape(gtx.Ops, bg, shape.Op(gtx.Ops))
+				lw := gtx.Dp(lineWidth)
+				paint.FillShape(gtx.Ops, line, clip.Rect{Min: image.Pt(0, size.Y-lw), Max: size}.Op())
+			} else {
+				paint.FillShape(gtx.Ops, bg, clip.UniformRRect(image.Rectangle{Max: size}, rr).Op(gtx.Ops))
+				kit.strokeRRect(gtx, size, kit.Radius.Medium, lineWidth, line)
+			}
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return in.layoutContent(gtx, showAffixes)
+			})
+		}),
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			if floating {
+				in.layoutFloatingLabel(gtx, float, focused, bg)
+			}
+			if in.overlay != nil {
+				in.overlay(gtx)
+			}
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}),
+	)
+}
+
+// layoutFloatingLabel draws the label at its eased position. It is drawn
+// at body size and scaled down as it moves up.
+func (in InputStyle) layoutFloatingLabel(gtx layout.Context, float float32, focused bool, bg color.NRGBA) {
+	kit := in.kit
+	rest, top := kit.Typography.BodyLarge, kit.Typography.LabelSmall
+	col := in.secondary()
+	switch {
+	case in.Disabled:
+		col = kit.Colors.TextDisabled
+	case in.HasError:
+		col = kit.Colors.Error
+	case focused:
+		col = kit.Colors.Primary500
+	}
+
+	macro := op.Record(gtx.Ops)
+	lgtx := gtx
+	lgtx.Constraints.Min = image.Point{}
+	dims := kit.Label(in.Label, rest, col).Layout(lgtx)
+	call := macro.Stop()
+
+	pad := gtx.Dp(kit.Spacing.Medium)
+	restX := pad
+	if in.PrefixIcon != nil {
+		restX += gtx.Sp(rest.LineHeight) + gtx.Dp(kit.Spacing.Small)
+	}
+	restY := pad
+	topY := -gtx.Sp(top.LineHeight) / 2
+	if in.Variant == InputFilled {
+		restY = gtx.Dp(kit.Spacing.Small) + gtx.Sp(top.LineHeight)
+		topY = gtx.Dp(kit.Spacing.Small)
+	}
+	scale := lerp(1, float32(top.Size)/float32(rest.Size), float)
+	x := lerp(float32(restX), float32(pad), float)
+	y := lerp(float32(restY), float32(topY), float)
+
+	// An outlined label cuts a gap in the border behind it
+	if in.Variant == InputOutlined && float > 0 {
+		gap := gtx.Dp(kit.Spacing.Tiny)
+		w := int(float32(dims.Size.X)*scale*float) + 2*gap
+		h := int(float32(dims.Size.Y) * scale)
+		rect := image.Rect(int(x)-gap, int(y), int(x)-gap+w, int(y)+h)
+		paint.FillShape(gtx.Ops, bg, clip.Rect(rect).Op())
+	}
+
+	tr := f32.Affine2D{}.Scale(f32.Point{}, f32.Pt(scale, scale)).Offset(f32.Pt(x, y))
+	defer op.Affine(tr).Push(gtx.Ops).Pop()
+	call.Add(gtx.Ops)
+}
+
+// layoutContent lays out the prefix icon and text, editor, suffix text and
+// icon, clear button and trailing widget in a row
+func (in InputStyle) layoutContent(gtx layout.Context, showAffixes bool) layout.Dimensions {
+	kit := in.kit
+	typo := kit.Typography.BodyLarge
+	iconSize := gtx.Sp(typo.LineHeight)
+	iconColor, textColor := in.secondary(), kit.Colors.OnSurface
+	switch {
+	case in.Disabled:
+		iconColor, textColor = kit.Colors.TextDisabled, kit.Colors.TextDisabled
+	case in.HasError:
+		iconColor = kit.Colors.Error
+	}
+	gap := layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout)
+	affix := func(text string) layout.FlexChild {
+		return layout.Rigid(kit.Label(text, typo, in.secondary()).Layout)
+	}
+
+	var children []layout.FlexChild
+	if in.PrefixIcon != nil {
+		children = append(children,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layoutIcon(gtx, in.PrefixIcon, iconSize, iconColor)
+			}),
+			gap,
+		)
+	}
+	if in.Prefix != "" && showAffixes {
+		children = append(children, affix(in.Prefix), gap)
+	}
+	hint := in.Hint
+	if !showAffixes {
+		hint = ""
+	}
+	children = append(children, layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+		lines := gtx.Sp(typo.LineHeight)
+		switch {
+		case in.height > 0:
+			gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		case in.maxLines > 0:
+			gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, in.maxLines*lines)
+		}
+		if in.minLines > 0 {
+			gtx.Constraints.Min.Y = max(gtx.Constraints.Min.Y, min(in.minLines*lines, gtx.Constraints.Max.Y))
+		}
+		if in.content != nil {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return in.content(gtx)
+		}
+		ed := material.Editor(kit.Theme, in.Editor, hint)
+		ed.Font = kit.font(typo)
+		ed.TextSize = typo.Size
+		ed.LineHeight = typo.LineHeight
+		ed.Color = textColor
+		ed.HintColor = in.secondary()
+		return ed.Layout(gtx)
+	}))
+	if in.Suffix != "" && showAffixes {
+		children = append(children, gap, affix(in.Suffix))
+	}
+	if in.SuffixIcon != nil {
+		children = append(children,
+			gap,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layoutIcon(gtx, in.SuffixIcon, iconSize, iconColor)
+			}),
+		)
+	}
+	if in.Clear != nil && in.Editor != nil && in.Editor.Len() > 0 && !in.Editor.ReadOnly {
+		children = append(children,
+			gap,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return in.layoutAction(gtx, in.Clear, kit.Icons.Get(IconClear), "Clear")
+			}),
+		)
+	}
+	if in.Trailing != nil {
+		children = append(children, gap, layout.Rigid(in.Trailing))
+	}
+	// Icons line up with the first line of a multiline input
+	align := layout.Middle
+	if in.minLines > 0 || in.height > 0 {
+		align = layout.Start
+	}
+	return layout.Flex{Alignment: align}.Layout(gtx, children...)
+}
+
+// layoutAction draws an icon button sized to the input's text. In a
+// disabled context it is dimmed and takes no clicks.
+func (in InputStyle) layoutAction(gtx layout.Context, btn *widget.Clickable, icon *Icon, description string) layout.Dimensions {
+	size := gtx.Sp(in.kit.Typography.BodyLarge.LineHeight)
+	if !gtx.Enabled() {
+		return layoutIcon(gtx, icon, size, in.kit.Colors.TextDisabled)
+	}
+	return btn.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		semantic.Button.Add(gtx.Ops)
+		semantic.DescriptionOp(description).Add(gtx.Ops)
+		dims := layoutIcon(gtx, icon, size, in.secondary())
+		defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+		pointer.CursorPointer.Add(gtx.Ops)
+		return dims
+	})
+}
+
+// tag returns the focus target, which also keys the label's transition
+func (in InputStyle) tag() event.Tag {
+	if in.Editor != nil {
+		return in.Editor
+	}
+	return in.focus
+}
+
+// isFilled reports whether the field holds a value
+func (in InputStyle) isFilled() bool {
+	if in.Editor != nil {
+		return in.Editor.Len() > 0
+	}
+	return in.filled
+}
+
+// colors returns the background and the border or underline color
+func (in InputStyle) colors(focused bool) (bg, line color.NRGBA) {
+	c := in.kit.Colors
+	bg, line = c.Surface, c.Border
+	if in.Variant == InputFilled {
+		bg, line = c.Gray100, in.secondary()
+	}
+	switch {
+	case in.Disabled:
+		line = c.BorderLight
+	case in.HasError:
+		line = c.Error
+	case focused:
+		line = c.Primary500
+	case in.ReadOnly:
+		line = c.BorderLight
+	}
+	return bg, line
+}
+
+// secondary returns the color of the hint, icons and resting label, which
+// needs more contrast on the filled background
+func (in InputStyle) secondary() color.NRGBA {
+	if in.Variant == InputFilled {
+		return in.kit.Colors.OnSurfaceVariant
+	}
+	return in.kit.Colors.TextSecondary
+}
@@ -0,0 +1,53 @@
+package uikit
+
+import "testing"
+
+func TestApplyMask(t *testing.T) {
+	tests := []struct {
+		mask, raw string
+		before    int
+		want      string
+		caret     int
+	}{
+		{MaskPhone, "555", 3, "(555", 4},
+		{MaskPhone, "5551", 4, "(555) 1", 7},
+		{MaskPhone, "5551234567890", 13, "(555) 123-4567", 14},
+		{MaskPhone, "5551234", 2, "(555) 123-4", 3},
+		{MaskDate, "1a2", 2, "12", 1},
+		{MaskCreditCard, "", 0, "", 0},
+		{"AA-99", "ab12", 4, "ab-12", 5},
+	}
+	for _, tt := range tests {
+		got, caret := applyMask(tt.mask, []rune(tt.raw), tt.before)
+		if got != tt.want || caret != tt.caret {
+			t.Errorf("applyMask(%q, %q, %d) = %q, %d; want %q, %d", tt.mask, tt.raw, tt.before, got, caret, tt.want, tt.caret)
+		}
+	}
+}
+
+func TestNumberFormat(t *testing.T) {
+	de := NumberFormatFor("de-DE")
+	if got := de.Format(-1234567.5, 2); got != "-1.234.567,50" {
+		t.Errorf("de format = %q", got)
+	}
+	if got := NumberFormatEnglish.Format(999, 0); got != "999" {
+		t.Errorf("en format = %q", got)
+	}
+	for _, tt := range []struct {
+		f    NumberFormat
+		s    string
+		want float64
+	}{
+		{de, "1.234,5", 1234.5},
+		{NumberFormatEnglish, "1,234.5", 1234.5},
+		{NumberFormatFor("fr"), "1 234,5", 1234.5},
+		{NumberFormatFor("de_CH"), "1'234.5", 1234.5},
+	} {
+		if got, err := tt.f.Parse(tt.s); err != nil || got != tt.want {
+			t.Errorf("parse %q = %v, %v", tt.s, got, err)
+		}
+	}
+	if _, err := NumberFormatEnglish.Parse("12a"); err == nil {
+		t.Error("parse accepted a letter")
+	}
+}
@@ -0,0 +1,34 @@
+// Package srgb converts 8-bit sRGB channels to and from linear light. It is
+// shared by the palette generator and the contrast package, which imports
+// the kit and so cannot be imported by it.
+package srgb
+
+import (
+	"image/color"
+	"math"
+)
+
+// Linear decodes an sRGB channel to linear light, from 0 to 1
+func Linear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// Encode converts linear light to an sRGB channel, clamping it to 0–1 first
+func Encode(c float64) uint8 {
+	c = math.Max(0, math.Min(1, c))
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(c * 255))
+}
+
+// Luminance returns the WCAG 2.x relative luminance of c, ignoring alpha
+func Luminance(c color.NRGBA) float64 {
+	return 0.2126*Linear(c.R) + 0.7152*Linear(c.G) + 0.0722*Linear(c.B)
+}
@@ -0,0 +1,166 @@
+package uikit
+
+import (
+	"strings"
+	"unicode"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// Input masks. In a mask 9 stands for a digit, A for a letter and * for
+// either; anything else is written as is, and must not be a letter or digit.
+const (
+	MaskPhone      = "(999) 999-9999"
+	MaskDate       = "99/99/9999"
+	MaskCreditCard = "9999 9999 9999 9999"
+)
+
+// MaskedInput holds the text of an input formatted to a Mask as it is typed
+type MaskedInput struct {
+	widget.Editor
+	Mask string
+
+	text string
+}
+
+// Raw returns the entered letters and digits without the mask's literals
+func (m *MaskedInput) Raw() string {
+	return string(maskRaw(m.Text()))
+}
+
+// SetRaw replaces the text with raw formatted to the mask
+func (m *MaskedInput) SetRaw(raw string) {
+	m.text, _ = applyMask(m.Mask, maskRaw(raw), 0)
+	m.SetText(m.text)
+}
+
+// Complete reports whether every position of the mask is filled
+func (m *MaskedInput) Complete() bool {
+	slots := 0
+	for _, r := range m.Mask {
+		if isMaskSlot(r) {
+			slots++
+		}
+	}
+	return len(maskRaw(m.Text())) == slots
+}
+
+// format reformats the text after an edit, keeping the caret after the
+// same typed character
+func (m *MaskedInput) format() {
+	text := m.Text()
+	if text == m.text {
+		return
+	}
+	caret, _ := m.Selection()
+	before := len(maskRaw(string([]rune(text)[:caret])))
+	formatted, caret := applyMask(m.Mask, maskRaw(text), before)
+	m.text = formatted
+	if formatted != text {
+		m.SetText(formatted)
+	}
+	m.SetCaret(caret, caret)
+}
+
+// MaskedInputStyle is an input that formats its text to a mask, such as a
+// phone number or date
+type MaskedInputStyle struct {
+	InputStyle
+	State *MaskedInput
+}
+
+// MaskedInput is a masked input with a floating label
+func (kit *UIKit) MaskedInput(state *MaskedInput, label string) layout.Widget {
+	return kit.MaskedInputStyle(state, label).Layout
+}
+
+// MaskedInputStyle returns a masked input that can be further configured
+// before layout. Its hint shows the mask with blanks for the positions.
+func (kit *UIKit) MaskedInputStyle(state *MaskedInput, label string) MaskedInputStyle {
+	hint := strings.Map(func(r rune) rune {
+		if isMaskSlot(r) {
+			return '_'
+		}
+		return r
+	}, state.Mask)
+	in := kit.InputStyle(&state.Editor, hint)
+	in.Label = label
+	in.LabelPlacement = InputLabelFloating
+	return MaskedInputStyle{InputStyle: in, State: state}
+}
+
+// Layout applies pending edits and formats them before drawing, so the
+// unformatted text is never shown
+func (p MaskedInputStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := p.State
+	s.SingleLine = true
+	for {
+		if _, ok := s.Editor.Update(gtx); !ok {
+			break
+		}
+	}
+	s.format()
+	return p.InputStyle.Layout(gtx)
+}
+
+func isMaskSlot(r rune) bool {
+	return r == '9' || r == 'A' || r == '*'
+}
+
+// fitsMask reports whether r may fill the mask position slot
+func fitsMask(slot, r rune) bool {
+	switch slot {
+	case '9':
+		return unicode.IsDigit(r)
+	case 'A':
+		return unicode.IsLetter(r)
+	}
+	return unicode.IsDigit(r) || unicode.IsLetter(r)
+}
+
+// maskRaw returns the letters and digits of s
+func maskRaw(s string) []rune {
+	var raw []rune
+	for _, r := range s {
+		if unicode.IsDigit(r) || unicode.IsLetter(r) {
+			raw = append(raw, r)
+		}
+	}
+	return raw
+}
+
+// applyMask fills the positions of mask with raw, skipping characters that
+// do not fit, and writes literals up to the last one filled. It also returns
+// the caret position after the first before characters of raw.
+func applyMask(mask string, raw []rune, before int) (string, int) {
+	var b []rune
+	i, caret := 0, 0
+	for _, m := range mask {
+		if i >= len(raw) {
+			break
+		}
+		if !isMaskSlot(m) {
+			b = append(b, m)
+			continue
+		}
+		for i < len(raw) && !fitsMask(m, raw[i]) {
+			i++
+			if i == before {
+				caret = len(b)
+			}
+		}
+		if i >= len(raw) {
+			break
+		}
+		b = append(b, raw[i])
+		i++
+		if i == before {
+			caret = len(b)
+		}
+	}
+	if before > i {
+		caret = len(b)
+	}
+	return string(b), caret
+}
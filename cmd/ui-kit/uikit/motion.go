@@ -0,0 +1,48 @@
+package uikit
+
+import (
+	"time"
+
+	"gioui.org/layout"
+
+	"uikit/uikit/anim"
+)
+
+// motionSweepInterval is how often, and after how long unused, transitions
+// of widgets that have left the screen are dropped
+const motionSweepInterval = time.Minute
+
+// motionEntry is the transition state of one widget
+type motionEntry struct {
+	tween anim.Tween
+	used  time.Time
+}
+
+// tween returns the transition state for a widget that has no state type of
+// its own to keep it in, such as a Button, keyed by its persistent state
+func (kit *UIKit) tween(gtx layout.Context, key any) *anim.Tween {
+	if kit.motions == nil {
+		kit.motions = make(map[any]*motionEntry)
+	}
+	e, ok := kit.motions[key]
+	if !ok {
+		e = new(motionEntry)
+		kit.motions[key] = e
+	}
+	e.used = gtx.Now
+
+	if gtx.Now.Sub(kit.motionSweep) > motionSweepInterval {
+		kit.motionSweep = gtx.Now
+		for k, e := range kit.motions {
+			if gtx.Now.Sub(e.used) > motionSweepInterval {
+				delete(kit.motions, k)
+			}
+		}
+	}
+	return &e.tween
+}
+
+// lerp interpolates between a and b as a transition value t goes from 0 to 1
+func lerp(a, b, t float32) float32 {
+	return a + (b-a)*t
+}
@@ -0,0 +1,291 @@
+package uikit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// NumberFormat holds the separators a locale writes numbers with
+type NumberFormat struct {
+	Decimal rune
+	// Group separates thousands; zero writes none
+	Group rune
+}
+
+// NumberFormatEnglish writes 1,234.5
+var NumberFormatEnglish = NumberFormat{Decimal: '.', Group: ','}
+
+// numberFormats maps languages to their separators where they differ from
+// English
+var numberFormats = map[string]NumberFormat{
+	"da": {Decimal: ',', Group: '.'},
+	"de": {Decimal: ',', Group: '.'},
+	"es": {Decimal: ',', Group: '.'},
+	"id": {Decimal: ',', Group: '.'},
+	"it": {Decimal: ',', Group: '.'},
+	"nl": {Decimal: ',', Group: '.'},
+	"pt": {Decimal: ',', Group: '.'},
+	"tr": {Decimal: ',', Group: '.'},
+	"cs": {Decimal: ',', Group: ' '},
+	"fi": {Decimal: ',', Group: ' '},
+	"fr": {Decimal: ',', Group: ' '},
+	"nb": {Decimal: ',', Group: ' '},
+	"pl": {Decimal: ',', Group: ' '},
+	"ru": {Decimal: ',', Group: ' '},
+	"sv": {Decimal: ',', Group: ' '},
+	"uk": {Decimal: ',', Group: ' '},
+}
+
+// NumberFormatFor returns the separators for a language tag such as
+// "de-DE", falling back to English for languages it does not know
+func NumberFormatFor(tag string) NumberFormat {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	lang, region, _ := strings.Cut(tag, "-")
+	if lang == "de" && region == "ch" {
+		return NumberFormat{Decimal: '.', Group: '\''}
+	}
+	if f, ok := numberFormats[lang]; ok {
+		return f
+	}
+	return NumberFormatEnglish
+}
+
+// Parse reads a number written in the format. Group separators and spaces
+// are ignored wherever they are.
+func (f NumberFormat) Parse(s string) (float64, error) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r == f.Decimal:
+			b.WriteByte('.')
+		case r == f.Group, unicode.IsSpace(r):
+		case r >= '0' && r <= '9', r == '-', r == '+':
+			b.WriteRune(r)
+		default:
+			return 0, fmt.Errorf("parse %q: unexpected %q", s, r)
+		}
+	}
+	return strconv.ParseFloat(b.String(), 64)
+}
+
+// Format writes v with the given number of decimals
+func (f NumberFormat) Format(v float64, decimals int) string {
+	if v == 0 {
+		v = 0 // Drop the sign of negative zero
+	}
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	var b strings.Builder
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		b.WriteByte('-')
+		s = rest
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	for i, r := range whole {
+		if i > 0 && f.Group != 0 && (len(whole)-i)%3 == 0 {
+			b.WriteRune(f.Group)
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteRune(f.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// NumberInput holds the text of a numeric input and the number it holds
+type NumberInput struct {
+	widget.Editor
+
+	value   float64
+	parsed  bool // The text is a number
+	ok      bool // and it is within range
+	set     bool // The text is rewritten from value on the next layout
+	text    string
+	focused bool
+	dec     widget.Clickable
+	inc     widget.Clickable
+}
+
+// Value returns the number last entered, and whether the text is a valid
+// number within range
+func (n *NumberInput) Value() (float64, bool) {
+	return n.value, n.ok
+}
+
+// SetValue replaces the text with v, formatted on the next layout
+func (n *NumberInput) SetValue(v float64) {
+	n.value, n.set = v, true
+}
+
+// NumberInputStyle is an input for a number with buttons to step it down
+// and up. The text is clamped and reformatted when the input loses focus.
+type NumberInputStyle struct {
+	InputStyle
+	State *NumberInput
+	// Min and Max bound the value; use math.Inf for an open end
+	Min, Max float64
+	// Step is what the stepper buttons add or subtract, counted from Min
+	Step float64
+	// Decimals to show; negative shows as many as Step has
+	Decimals int
+	Format   NumberFormat
+}
+
+// NumberInput is a number input with a floating label and stepper buttons
+func (kit *UIKit) NumberInput(state *NumberInput, label string, min, max, step float64) layout.Widget {
+	return kit.NumberInputStyle(state, label, min, max, step).Layout
+}
+
+// NumberInputStyle returns a number input that can be further configured
+// before layout
+func (kit *UIKit) NumberInputStyle(state *NumberInput, label string, min, max, step float64) NumberInputStyle {
+	in := kit.InputStyle(&state.Editor, "")
+	in.Label = label
+	in.LabelPlacement = InputLabelFloating
+	return NumberInputStyle{
+		InputStyle: in,
+		State:      state,
+		Min:        min,
+		Max:        max,
+		Step:       step,
+		Decimals:   -1,
+		Format:     NumberFormatEnglish,
+	}
+}
+
+// Layout draws the input with its steppers, and an error while the text is
+// not a number within range
+func (n NumberInputStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := n.State
+	s.SingleLine = true
+	s.Filter = "0123456789+-" + string(n.Format.Decimal)
+	if n.Format.Group != 0 {
+		s.Filter += string(n.Format.Group)
+	}
+	if !n.ReadOnly && !n.Disabled {
+		for s.dec.Clicked(gtx) {
+			n.step(-1)
+		}
+		for s.inc.Clicked(gtx) {
+			n.step(1)
+		}
+	}
+
+	// Apply this frame's typing first, so the value and error keep up
+	for {
+		if _, ok := s.Editor.Update(gtx); !ok {
+			break
+		}
+	}
+	if text := s.Text(); text != s.text {
+		s.text = text
+		v, err := n.Format.Parse(text)
+		s.parsed = err == nil
+		if s.parsed {
+			s.value = v
+		}
+	}
+	focused := gtx.Focused(&s.Editor)
+	blurred := s.focused && !focused
+	s.focused = focused
+	if s.set || blurred && s.parsed {
+		s.value = n.clamp(s.value)
+		s.SetText(n.Format.Format(s.value, n.decimals()))
+		s.text, s.parsed, s.set = s.Text(), true, false
+	}
+	inRange := s.value >= n.Min && s.value <= n.Max
+	s.ok = s.parsed && inRange
+
+	if n.Error == "" {
+		switch {
+		case s.text != "" && !s.parsed:
+			n.Error = "Enter a number"
+		case s.parsed && !inRange:
+			n.Error = n.rangeError()
+		}
+	}
+	n.Trailing = n.layoutSteppers
+	return n.InputStyle.Layout(gtx)
+}
+
+// layoutSteppers lays out the down and up buttons, each disabled at its end
+// of the range
+func (n NumberInputStyle) layoutSteppers(gtx layout.Context) layout.Dimensions {
+	s := n.State
+	kit := n.kit
+	stepper := func(btn *widget.Clickable, icon, description string, enabled bool) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !enabled || n.ReadOnly {
+				gtx = gtx.Disabled()
+			}
+			return n.layoutAction(gtx, btn, kit.Icons.Get(icon), description)
+		})
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		stepper(&s.dec, IconRemove, "Decrease", !s.parsed || s.value > n.Min),
+		layout.Rigid(layout.Spacer{Width: kit.Spacing.Tiny}.Layout),
+		stepper(&s.inc, IconAdd, "Increase", !s.parsed || s.value < n.Max),
+	)
+}
+
+// step moves the value dir steps along the grid of steps from Min. An
+// empty or invalid input starts from zero, or the nearest bound.
+func (n NumberInputStyle) step(dir float64) {
+	s := n.State
+	v := s.value
+	if !s.parsed {
+		v = n.clamp(0)
+	}
+	size := n.Step
+	if size <= 0 {
+		size = 1
+	}
+	var base float64
+	if !math.IsInf(n.Min, 0) {
+		base = n.Min
+	}
+	v = base + (math.Round((v-base)/size)+dir)*size
+	s.SetValue(n.clamp(v))
+}
+
+func (n NumberInputStyle) clamp(v float64) float64 {
+	return max(n.Min, min(n.Max, v))
+}
+
+// decimals returns how many decimals to format with
+func (n NumberInputStyle) decimals() int {
+	if n.Decimals >= 0 {
+		return n.Decimals
+	}
+	return stepDecimals(n.Step, 64)
+}
+
+// stepDecimals returns how many decimals it takes to write multiples of
+// step, up to six. bitSize is 32 for a step held in a float32, so that it
+// is written as the shortest float32 rather than with its binary error.
+func stepDecimals(step float64, bitSize int) int {
+	s := strconv.FormatFloat(step, 'f', -1, bitSize)
+	if _, frac, ok := strings.Cut(s, "."); ok {
+		return min(len(frac), 6)
+	}
+	return 0
+}
+
+// rangeError describes the range for a value outside it
+func (n NumberInputStyle) rangeError() string {
+	low, high := n.Format.Format(n.Min, n.decimals()), n.Format.Format(n.Max, n.decimals())
+	switch {
+	case math.IsInf(n.Max, 1):
+		return "Enter at least " + low
+	case math.IsInf(n.Min, -1):
+		return "Enter at most " + high
+	}
+	return fmt.Sprintf("Enter a number from %s to %s", low, high)
+}
@@ -0,0 +1,273 @@
+package uikit
+
+import (
+	"image/color"
+	"math"
+
+	"uikit/uikit/internal/srgb"
+)
+
+// PaletteSeeds are the brand colors a palette is generated from. Any seed
+// left at its zero value falls back to the hue of the default palette; a zero
+// Neutral is derived from Primary's hue with a low chroma.
+type PaletteSeeds struct {
+	Primary color.NRGBA
+	Neutral color.NRGBA
+	Success color.NRGBA
+	Warning color.NRGBA
+	Error   color.NRGBA
+	Info    color.NRGBA
+}
+
+// DefaultPaletteSeeds returns the seeds matching the hues of NewColorPalette
+func DefaultPaletteSeeds() PaletteSeeds {
+	return PaletteSeeds{
+		Primary: color.NRGBA{R: 0x64, G: 0x74, B: 0x8B, A: 0xFF},
+		Neutral: color.NRGBA{R: 0x6B, G: 0x72, B: 0x80, A: 0xFF},
+		Success: color.NRGBA{R: 0x04, G: 0x78, B: 0x57, A: 0xFF},
+		Warning: color.NRGBA{R: 0xB4, G: 0x53, B: 0x09, A: 0xFF},
+		Error:   color.NRGBA{R: 0xDC, G: 0x26, B: 0x26, A: 0xFF},
+		Info:    color.NRGBA{R: 0x25, G: 0x63, B: 0xEB, A: 0xFF},
+	}
+}
+
+// Tonal scale steps, 50 through 900. Lightness is fixed per step so that
+// every brand gets the same contrast between steps; the seed only
+// contributes hue and chroma, which tapers off towards both ends.
+var (
+	tonalLightness = [10]float64{0.985, 0.967, 0.929, 0.869, 0.704, 0.554, 0.446, 0.372, 0.279, 0.208}
+	tonalChroma    = [10]float64{0.08, 0.16, 0.32, 0.55, 0.85, 1.0, 0.95, 0.85, 0.7, 0.55}
+)
+
+// TonalScale generates the ten steps (50, 100, 200 … 900) for a seed color
+// in the OKLCH color space. Colors outside the sRGB gamut are brought back
+// in by reducing chroma, keeping lightness and hue intact.
+func TonalScale(seed color.NRGBA) [10]color.NRGBA {
+	_, c, h := toOKLCH(seed)
+	var scale [10]color.NRGBA
+	for i := range scale {
+		scale[i] = fromOKLCH(tonalLightness[i], c*tonalChroma[i], h)
+	}
+	return scale
+}
+
+// NewColorPaletteFromSeeds generates a complete light palette from seeds
+func NewColorPaletteFromSeeds(seeds PaletteSeeds) ColorPalette {
+	return generatePalette(seeds, false)
+}
+
+// NewDarkColorPaletteFromSeeds generates a complete dark palette from seeds,
+// inverting the scales the same way NewDarkColorPalette does
+func NewDarkColorPaletteFromSeeds(seeds PaletteSeeds) ColorPalette {
+	return generatePalette(seeds, true)
+}
+
+// SetSeeds brands the kit with generated palettes. Later SetTheme calls
+// regenerate from the same seeds instead of using the default palettes.
+func (kit *UIKit) SetSeeds(seeds PaletteSeeds) {
+	kit.seeds = &seeds
+	kit.SetTheme(kit.mode)
+}
+
+func generatePalette(seeds PaletteSeeds, dark bool) ColorPalette {
+	def := DefaultPaletteSeeds()
+	if seeds.Primary == (color.NRGBA{}) {
+		seeds.Primary = def.Primary
+	}
+	if seeds.Neutral == (color.NRGBA{}) {
+		_, c, h := toOKLCH(seeds.Primary)
+		seeds.Neutral = fromOKLCH(0.554, math.Min(c, 0.03), h)
+	}
+	if seeds.Success == (color.NRGBA{}) {
+		seeds.Success = def.Success
+	}
+	if seeds.Warning == (color.NRGBA{}) {
+		seeds.Warning = def.Warning
+	}
+	if seeds.Error == (color.NRGBA{}) {
+		seeds.Error = def.Error
+	}
+	if seeds.Info == (color.NRGBA{}) {
+		seeds.Info = def.Info
+	}
+
+	primary := TonalScale(seeds.Primary)
+	gray := TonalScale(seeds.Neutral)
+	success := TonalScale(seeds.Success)
+	warning := TonalScale(seeds.Warning)
+	danger := TonalScale(seeds.Error)
+	info := TonalScale(seeds.Info)
+
+	white := color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+	// Step indices for the semantic colors: the accent tone, its container and
+	// the text drawn on the container. Light palettes use the 600 step so the
+	// accent also carries white text; dark palettes use lighter accents on
+	// deep containers.
+	accent, container, onContainer := 6, 1, 8
+	if dark {
+		for i := 0; i < 5; i++ {
+			primary[i], primary[9-i] = primary[9-i], primary[i]
+			gray[i], gray[9-i] = gray[9-i], gray[i]
+		}
+		accent, container, onContainer = 4, 9, 1
+	}
+
+	p := ColorPalette{
+		Primary50: primary[0], Primary100: primary[1], Primary200: primary[2],
+		Primary300: primary[3], Primary400: primary[4], Primary500: primary[5],
+		Primary600: primary[6], Primary700: primary[7], Primary800: primary[8],
+		Primary900: primary[9],
+
+		Gray50: gray[0], Gray100: gray[1], Gray200: gray[2], Gray300: gray[3],
+		Gray400: gray[4], Gray500: gray[5], Gray600: gray[6], Gray700: gray[7],
+		Gray800: gray[8], Gray900: gray[9],
+
+		Success: success[accent], SuccessLight: success[container],
+		Warning: warning[accent], WarningLight: warning[container],
+		Error: danger[accent], ErrorLight: danger[container],
+		Info: info[accent], InfoLight: info[container],
+
+		OnSuccessLight: success[onContainer],
+		OnWarningLight: warning[onContainer],
+		OnErrorLight:   danger[onContainer],
+		OnInfoLight:    info[onContainer],
+
+		White: white,
+
+		Shadow:  color.NRGBA{A: 0x0F},
+		Overlay: color.NRGBA{A: 0x40},
+		Focus:   withAlpha(info[accent], 0x60),
+	}
+
+	if dark {
+		p.Background = gray[0]
+		p.Surface = gray[1]
+		p.SurfaceElevated = mix(gray[1], gray[2], 0.5)
+		p.Border = gray[2]
+		p.BorderLight = mix(gray[1], gray[2], 0.5)
+		p.BorderHover = gray[3]
+		p.Shadow = color.NRGBA{A: 0x40}
+		p.Overlay = color.NRGBA{A: 0x99}
+		p.Focus = withAlpha(info[accent], 0x80)
+	} else {
+		p.Background = gray[0]
+		p.Surface = white
+		p.SurfaceElevated = white
+		p.Border = gray[2]
+		p.BorderLight = gray[1]
+		p.BorderHover = gray[3]
+	}
+
+	p.TextPrimary = gray[9]
+	p.TextSecondary = gray[5]
+	p.TextDisabled = gray[4]
+	p.TextInverse = gray[0]
+	if !dark {
+		p.TextInverse = white
+	}
+
+	// On colors pick whichever of the darkest and lightest neutral reads
+	// better on the color underneath.
+	ink, paper := gray[9], white
+	if dark {
+		ink, paper = gray[0], gray[9]
+	}
+	on := func(bg color.NRGBA) color.NRGBA {
+		return pickReadable(bg, ink, paper)
+	}
+	p.OnBackground = on(p.Background)
+	p.OnSurface = on(p.Surface)
+	p.OnPrimary = on(p.Primary500)
+	p.OnSecondary = on(p.Gray100)
+	p.OnError = on(p.Error)
+	p.OnSuccess = on(p.Success)
+	p.OnWarning = on(p.Warning)
+	p.OnInfo = on(p.Info)
+	p.OnSurfaceElevated = on(p.SurfaceElevated)
+	p.OnSurfaceDisabled = p.TextDisabled
+	p.OnSurfaceVariant = primary[6]
+
+	return p
+}
+
+// pickReadable returns whichever of a and b has the higher WCAG contrast against bg
+func pickReadable(bg, a, b color.NRGBA) color.NRGBA {
+	lbg := srgb.Luminance(bg)
+	ratio := func(c color.NRGBA) float64 {
+		l := srgb.Luminance(c)
+		return (math.Max(l, lbg) + 0.05) / (math.Min(l, lbg) + 0.05)
+	}
+	if ratio(a) >= ratio(b) {
+		return a
+	}
+	return b
+}
+
+func withAlpha(c color.NRGBA, a uint8) color.NRGBA {
+	c.A = a
+	return c
+}
+
+// mix interpolates between two opaque colors in OKLab
+func mix(a, b color.NRGBA, t float64) color.NRGBA {
+	la, aa, ba := toOKLab(a)
+	lb, ab, bb := toOKLab(b)
+	return fromOKLab(la+(lb-la)*t, aa+(ab-aa)*t, ba+(bb-ba)*t)
+}
+
+// OKLab/OKLCH conversions, after Björn Ottosson's reference implementation.
+
+func toOKLCH(c color.NRGBA) (l, chroma, hue float64) {
+	l, a, b := toOKLab(c)
+	return l, math.Hypot(a, b), math.Atan2(b, a)
+}
+
+// fromOKLCH converts to sRGB, reducing chroma until the color is in gamut
+func fromOKLCH(l, chroma, hue float64) color.NRGBA {
+	a, b := chroma*math.Cos(hue), chroma*math.Sin(hue)
+	if inGamut(l, a, b) {
+		return fromOKLab(l, a, b)
+	}
+	lo, hi := 0.0, chroma
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if inGamut(l, mid*math.Cos(hue), mid*math.Sin(hue)) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return fromOKLab(l, lo*math.Cos(hue), lo*math.Sin(hue))
+}
+
+func toOKLab(c color.NRGBA) (l, a, b float64) {
+	r, g, bl := srgb.Linear(c.R), srgb.Linear(c.G), srgb.Linear(c.B)
+	lc := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	mc := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	sc := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+	return 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc,
+		1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc,
+		0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+}
+
+func okLabToLinear(l, a, b float64) (r, g, bl float64) {
+	lc := l + 0.3963377774*a + 0.2158037573*b
+	mc := l - 0.1055613458*a - 0.0638541728*b
+	sc := l - 0.0894841775*a - 1.2914855480*b
+	lc, mc, sc = lc*lc*lc, mc*mc*mc, sc*sc*sc
+	return 4.0767416621*lc - 3.3077115913*mc + 0.2309699292*sc,
+		-1.2684380046*lc + 2.6097574011*mc - 0.3413193965*sc,
+		-0.0041960863*lc - 0.7034186147*mc + 1.7076147010*sc
+}
+
+func inGamut(l, a, b float64) bool {
+	const eps = 1e-4
+	r, g, bl := okLabToLinear(l, a, b)
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && bl >= -eps && bl <= 1+eps
+}
+
+func fromOKLab(l, a, b float64) color.NRGBA {
+	r, g, bl := okLabToLinear(l, a, b)
+	return color.NRGBA{R: srgb.Encode(r), G: srgb.Encode(g), B: srgb.Encode(bl), A: 0xFF}
+}
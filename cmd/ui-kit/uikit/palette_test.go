@@ -0,0 +1,54 @@
+package uikit
+
+import (
+	"image/color"
+	"testing"
+
+	"uikit/uikit/internal/srgb"
+)
+
+// testSeeds are brand colors across the hue wheel, including a saturated
+// yellow whose light steps fall far outside the sRGB gamut
+var testSeeds = map[string]color.NRGBA{
+	"slate":   {R: 0x64, G: 0x74, B: 0x8B, A: 0xFF},
+	"violet":  {R: 0x7C, G: 0x3A, B: 0xED, A: 0xFF},
+	"teal":    {R: 0x0D, G: 0x94, B: 0x88, A: 0xFF},
+	"orange":  {R: 0xF9, G: 0x73, B: 0x16, A: 0xFF},
+	"yellow":  {R: 0xFF, G: 0xEB, B: 0x00, A: 0xFF},
+	"magenta": {R: 0xDB, G: 0x27, B: 0x77, A: 0xFF},
+	"gray":    {R: 0x80, G: 0x80, B: 0x80, A: 0xFF},
+}
+
+func TestTonalScaleMonotonic(t *testing.T) {
+	for name, seed := range testSeeds {
+		scale := TonalScale(seed)
+		for i := 1; i < len(scale); i++ {
+			if srgb.Luminance(scale[i]) >= srgb.Luminance(scale[i-1]) {
+				t.Errorf("%s: step %d (%v) is not darker than step %d (%v)", name, i, scale[i], i-1, scale[i-1])
+			}
+		}
+	}
+}
+
+func TestGeneratedRamps(t *testing.T) {
+	for name, seed := range testSeeds {
+		for _, dark := range []bool{false, true} {
+			p := generatePalette(PaletteSeeds{Primary: seed}, dark)
+			ramps := map[string][10]color.NRGBA{
+				"primary": {p.Primary50, p.Primary100, p.Primary200, p.Primary300, p.Primary400,
+					p.Primary500, p.Primary600, p.Primary700, p.Primary800, p.Primary900},
+				"gray": {p.Gray50, p.Gray100, p.Gray200, p.Gray300, p.Gray400,
+					p.Gray500, p.Gray600, p.Gray700, p.Gray800, p.Gray900},
+			}
+			for ramp, steps := range ramps {
+				for i := 1; i < len(steps); i++ {
+					// Dark palettes run from dark to light
+					darker := srgb.Luminance(steps[i]) < srgb.Luminance(steps[i-1])
+					if darker == dark {
+						t.Errorf("%s, dark %v: %s step %d does not continue the ramp", name, dark, ramp, i)
+					}
+				}
+			}
+		}
+	}
+}
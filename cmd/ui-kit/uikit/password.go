@@ -0,0 +1,60 @@
+package uikit
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// passwordMask replaces each character of a hidden password
+const passwordMask = '•'
+
+// PasswordInput holds the text of a password input and whether it is shown
+type PasswordInput struct {
+	widget.Editor
+	// Visible shows the password in plain text
+	Visible bool
+
+	toggle widget.Clickable
+}
+
+// PasswordInputStyle is an input whose text is hidden until the user
+// toggles it visible
+type PasswordInputStyle struct {
+	InputStyle
+	State *PasswordInput
+}
+
+// PasswordInput is a password input with a floating label and a show/hide
+// toggle
+func (kit *UIKit) PasswordInput(state *PasswordInput, label string) layout.Widget {
+	return kit.PasswordInputStyle(state, label).Layout
+}
+
+// PasswordInputStyle returns a password input that can be further
+// configured before layout
+func (kit *UIKit) PasswordInputStyle(state *PasswordInput, label string) PasswordInputStyle {
+	in := kit.InputStyle(&state.Editor, "")
+	in.Label = label
+	in.LabelPlacement = InputLabelFloating
+	in.PrefixIcon = kit.Icons.Get(IconLock)
+	return PasswordInputStyle{InputStyle: in, State: state}
+}
+
+// Layout draws the input with the visibility toggle at its end
+func (p PasswordInputStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := p.State
+	for s.toggle.Clicked(gtx) {
+		s.Visible = !s.Visible
+	}
+	s.SingleLine = true
+	s.Mask = passwordMask
+	icon, description := IconVisibility, "Show password"
+	if s.Visible {
+		s.Mask = 0
+		icon, description = IconVisibilityOff, "Hide password"
+	}
+	p.Trailing = func(gtx layout.Context) layout.Dimensions {
+		return p.layoutAction(gtx, &s.toggle, p.kit.Icons.Get(icon), description)
+	}
+	return p.InputStyle.Layout(gtx)
+}
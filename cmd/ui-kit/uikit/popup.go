@@ -0,0 +1,219 @@
+package uikit
+
+import (
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/io/event"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+const (
+	// popupGap separates a popup from the field it belongs to
+	popupGap = unit.Dp(4)
+	// popupMargin keeps popups off the window edges
+	popupMargin = unit.Dp(8)
+	// popupScrim is how far a popup's scrim reaches in every direction,
+	// enough to cover any window from wherever the popup is
+	popupScrim = 1 << 20
+)
+
+// windowState is what TrackWindow learns about the window. Gio reports
+// pointer positions relative to each handler, so an event seen both here
+// and by a widget tells where the widget sits in the window.
+type windowState struct {
+	size   image.Point
+	events [16]pointer.Event // Recent pointer events, as a ring
+	next   int
+}
+
+// TrackWindow records the window's size and where pointer events land in
+// it, so popups such as a Select's menu can keep inside the window. Lay it
+// out after the content, with the constraints of the whole window, like
+// Modal. Without it, popups always open below their field.
+//
+// A field's place in the window is learned from the pointer moving
+// anywhere over the window. Until it has moved, or after the field has
+// scrolled without it moving, popups go by the height the field's parent
+// gives it: when that reaches the bottom of the window, as in a column
+// filling it, they still flip above the field near the bottom; inside a
+// scrolling list, they open below.
+func (kit *UIKit) TrackWindow(gtx layout.Context) layout.Dimensions {
+	w := &kit.window
+	w.update(gtx)
+	w.size = gtx.Constraints.Max
+	defer pointer.PassOp{}.Push(gtx.Ops).Pop()
+	defer clip.Rect{Max: w.size}.Push(gtx.Ops).Pop()
+	event.Op(gtx.Ops, w)
+	return layout.Dimensions{}
+}
+
+func (w *windowState) update(gtx layout.Context) {
+	for {
+		ev, ok := gtx.Event(pointer.Filter{
+			Target: w,
+			Kinds:  pointer.Press | pointer.Release | pointer.Move | pointer.Drag | pointer.Enter | pointer.Leave,
+		})
+		if !ok {
+			break
+		}
+		if ev, ok := ev.(pointer.Event); ok {
+			w.events[w.next] = ev
+			w.next = (w.next + 1) % len(w.events)
+		}
+	}
+}
+
+// origin returns where the origin of the handler that received ev lies in
+// the window, if TrackWindow saw the same event. The handler may have seen
+// it as an Enter where the window saw a Move, so kinds are not compared.
+func (w *windowState) origin(gtx layout.Context, ev pointer.Event) (image.Point, bool) {
+	w.update(gtx)
+	for _, seen := range w.events {
+		if ev.Time != 0 && seen.Time == ev.Time && seen.PointerID == ev.PointerID && seen.Source == ev.Source {
+			return seen.Position.Sub(ev.Position).Round(), true
+		}
+	}
+	return image.Point{}, false
+}
+
+// popupAnchor is where a popup hangs from: a field of some size, at an
+// origin in the window if known
+type popupAnchor struct {
+	size    image.Point
+	origin  image.Point
+	located bool
+	// avail is the height the field's parent had for it and what follows,
+	// used to guess its place before the pointer has been seen
+	avail int
+}
+
+// track lets a see pointer movement anywhere in the window for the rest
+// of the frame, passing it on to whatever is under the pointer, so that
+// locate can place it without the pointer ever passing over the field
+func (a *popupAnchor) track(gtx layout.Context) {
+	macro := op.Record(gtx.Ops)
+	pass := pointer.PassOp{}.Push(gtx.Ops)
+	area := clip.Rect{Min: image.Pt(-popupScrim, -popupScrim), Max: image.Pt(popupScrim, popupScrim)}.Push(gtx.Ops)
+	event.Op(gtx.Ops, a)
+	area.Pop()
+	pass.Pop()
+	op.Defer(gtx.Ops, macro.Stop())
+}
+
+// locate places a in the window from the pointer events it has seen
+func (a *popupAnchor) locate(gtx layout.Context, w *windowState) {
+	for {
+		ev, ok := gtx.Event(pointer.Filter{Target: a, Kinds: pointer.Move | pointer.Enter})
+		if !ok {
+			break
+		}
+		if e, ok := ev.(pointer.Event); ok && w != nil {
+			if origin, ok := w.origin(gtx, e); ok {
+				a.origin, a.located = origin, true
+			}
+		}
+	}
+}
+
+// popupFrame is the window a popup must fit in, with its spacing in pixels
+type popupFrame struct {
+	win    image.Point // Zero if unknown
+	gap    int
+	margin int
+}
+
+// room returns the height free below and above the anchor. An anchor the
+// pointer has not located yet is assumed to have its avail height reach
+// the bottom of the window, when that height is known and fits in it;
+// otherwise everything is taken to be below it, up to max.
+func (f popupFrame) room(a popupAnchor, max int) (below, above int) {
+	if f.win == (image.Point{}) {
+		return max, 0
+	}
+	top := a.origin.Y
+	if !a.located {
+		if a.avail <= 0 || a.avail > f.win.Y {
+			return max, 0
+		}
+		top = f.win.Y - a.avail
+	}
+	return f.win.Y - top - a.size.Y - f.gap - f.margin, top - f.gap - f.margin
+}
+
+// side decides whether a popup of the given height goes above the anchor,
+// which it does when it does not fit below and there is more room above,
+// and returns the height it must fit in there
+func (f popupFrame) side(height, below, above int) (flip bool, room int) {
+	if height > below && above > below {
+		return true, above
+	}
+	return false, below
+}
+
+// offset returns where a popup of size goes relative to the anchor's
+// origin, shifted sideways to keep inside the window if the anchor is
+// located
+func (f popupFrame) offset(a popupAnchor, size image.Point, flip bool) image.Point {
+	pos := image.Pt(0, a.size.Y+f.gap)
+	if flip {
+		pos.Y = -f.gap - size.Y
+	}
+	if a.located && f.win != (image.Point{}) {
+		if right := a.origin.X + size.X - (f.win.X - f.margin); right > 0 {
+			pos.X -= right
+		}
+		pos.X = max(pos.X, f.margin-a.origin.X)
+	}
+	return pos
+}
+
+// layoutPopup draws w above everything else, below the anchor or above it
+// when there is more room there, and shifted sideways to stay in the
+// window. w is laid out at most as wide as the anchor and as tall as the
+// room allows; it may be laid out twice. A click on the scrim around the
+// popup is reported to scrim, if not nil; a passing scrim lets the click
+// through to what is under it as well.
+func (kit *UIKit) layoutPopup(gtx layout.Context, a popupAnchor, scrim *gesture.Click, pass bool, w layout.Widget) {
+	f := popupFrame{win: kit.window.size, gap: gtx.Dp(popupGap), margin: gtx.Dp(popupMargin)}
+	below, above := f.room(a, gtx.Constraints.Max.Y)
+	pgtx := gtx
+	pgtx.Constraints = layout.Constraints{
+		Min: image.Pt(a.size.X, 0),
+		Max: image.Pt(a.size.X, max(below, above, 0)),
+	}
+	macro := op.Record(gtx.Ops)
+	dims := w(pgtx)
+	call := macro.Stop()
+
+	flip, room := f.side(dims.Size.Y, below, above)
+	if dims.Size.Y > room {
+		pgtx.Constraints.Max.Y = max(room, 0)
+		macro = op.Record(gtx.Ops)
+		dims = w(pgtx)
+		call = macro.Stop()
+	}
+	pos := f.offset(a, dims.Size, flip)
+
+	macro = op.Record(gtx.Ops)
+	if scrim != nil {
+		var through pointer.PassStack
+		if pass {
+			through = pointer.PassOp{}.Push(gtx.Ops)
+		}
+		area := clip.Rect{Min: image.Pt(-popupScrim, -popupScrim), Max: image.Pt(popupScrim, popupScrim)}.Push(gtx.Ops)
+		scrim.Add(gtx.Ops)
+		area.Pop()
+		if pass {
+			through.Pop()
+		}
+	}
+	off := op.Offset(pos).Push(gtx.Ops)
+	call.Add(gtx.Ops)
+	off.Pop()
+	op.Defer(gtx.Ops, macro.Stop())
+}
@@ -0,0 +1,47 @@
+package uikit
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPopupPlacement(t *testing.T) {
+	f := popupFrame{win: image.Pt(800, 600), gap: 4, margin: 8}
+	field := image.Pt(200, 40)
+	menu := image.Pt(200, 200)
+	tests := []struct {
+		name   string
+		frame  popupFrame
+		anchor popupAnchor
+		flip   bool
+		room   int
+		pos    image.Point
+	}{
+		{"top of window", f, popupAnchor{size: field, origin: image.Pt(100, 100), located: true},
+			false, 448, image.Pt(0, 44)},
+		{"bottom of window", f, popupAnchor{size: field, origin: image.Pt(100, 500), located: true},
+			true, 488, image.Pt(0, -204)},
+		{"right edge", f, popupAnchor{size: field, origin: image.Pt(700, 100), located: true},
+			false, 448, image.Pt(-108, 44)},
+		{"left edge", f, popupAnchor{size: field, origin: image.Pt(-50, 100), located: true},
+			false, 448, image.Pt(58, 44)},
+		// Not located by the pointer, in a column reaching the window bottom
+		{"unlocated, bottom of column", f, popupAnchor{size: field, avail: 100},
+			true, 488, image.Pt(0, -204)},
+		{"unlocated, top of column", f, popupAnchor{size: field, avail: 500},
+			false, 448, image.Pt(0, 44)},
+		// Not located, in a scrolling list: the height says nothing
+		{"unlocated, in a list", f, popupAnchor{size: field, avail: 1e6},
+			false, 1000, image.Pt(0, 44)},
+		{"no TrackWindow", popupFrame{gap: 4, margin: 8}, popupAnchor{size: field, origin: image.Pt(100, 500), located: true},
+			false, 1000, image.Pt(0, 44)},
+	}
+	for _, tt := range tests {
+		below, above := tt.frame.room(tt.anchor, 1000)
+		flip, room := tt.frame.side(menu.Y, below, above)
+		pos := tt.frame.offset(tt.anchor, menu, flip)
+		if flip != tt.flip || room != tt.room || pos != tt.pos {
+			t.Errorf("%s: flip %v in %d at %v; want flip %v in %d at %v", tt.name, flip, room, pos, tt.flip, tt.room, tt.pos)
+		}
+	}
+}
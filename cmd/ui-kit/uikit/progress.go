@@ -0,0 +1,303 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+
+	"uikit/uikit/anim"
+)
+
+// Progress colors
+type ProgressVariant int
+
+const (
+	ProgressPrimary ProgressVariant = iota
+	ProgressSuccess
+	ProgressWarning
+	ProgressError
+)
+
+// Indeterminate animation periods
+const (
+	linearSweepPeriod  = 1500 * time.Millisecond
+	spinnerTurnPeriod  = 1400 * time.Millisecond
+	spinnerSweepPeriod = 2800 * time.Millisecond
+)
+
+// bufferAlpha tints the buffered part of a track with the fill color
+const bufferAlpha = 0x59 // 35%
+
+// ProgressState eases an indicator's fill towards each new value instead of
+// jumping there. It is optional; keep one per animated indicator.
+type ProgressState struct {
+	fill   anim.Tween
+	buffer anim.Tween
+}
+
+// animate eases t towards target over the kit's slow motion duration
+func (kit *UIKit) animate(gtx layout.Context, t *anim.Tween, target float32) float32 {
+	t.Duration = kit.Motion.Duration(kit.Motion.Slow)
+	t.To(target)
+	return t.Value(gtx)
+}
+
+// ProgressStyle describes a linear progress bar
+type ProgressStyle struct {
+	// Progress is the completed fraction, from 0 to 1
+	Progress float32
+	// Buffer is a secondary fraction drawn behind Progress in a lighter
+	// tint, e.g. how much of a stream has been downloaded
+	Buffer float32
+	// Indeterminate ignores Progress and animates a sliding segment
+	Indeterminate bool
+	Variant       ProgressVariant
+	// Label is drawn to the right of the bar when not empty
+	Label     string
+	Thickness unit.Dp
+	// State animates changes to Progress and Buffer when set
+	State *ProgressState
+
+	kit *UIKit
+}
+
+// Progress bar component
+func (kit *UIKit) ProgressBar(progress float32) layout.Widget {
+	return kit.ProgressStyle(progress).Layout
+}
+
+// ProgressStyle returns a progress bar that can be further configured before layout
+func (kit *UIKit) ProgressStyle(progress float32) ProgressStyle {
+	return ProgressStyle{
+		Progress:  progress,
+		Thickness: kit.Spacing.Small,
+		kit:       kit,
+	}
+}
+
+// Layout draws the bar across the available width
+func (p ProgressStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := p.kit
+	if p.Label == "" {
+		return p.layoutBar(gtx)
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, p.layoutBar),
+		layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout),
+		layout.Rigid(kit.Label(p.Label, kit.Typography.LabelSmall, kit.Colors.TextSecondary).Layout),
+	)
+}
+
+func (p ProgressStyle) layoutBar(gtx layout.Context) layout.Dimensions {
+	kit := p.kit
+	size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(p.Thickness))
+	radius := size.Y / 2
+	fill := kit.progressColor(p.Variant)
+
+	track := clip.UniformRRect(image.Rectangle{Max: size}, radius)
+	paint.FillShape(gtx.Ops, kit.Colors.Gray200, track.Op(gtx.Ops))
+	defer track.Push(gtx.Ops).Pop()
+
+	if p.Indeterminate {
+		// A segment half the track's width slides in from the left and out to the right
+		t := phase(gtx.Now, linearSweepPeriod)
+		seg := size.X / 2
+		x := int(t*float32(size.X+seg)) - seg
+		paint.FillShape(gtx.Ops, fill, clip.UniformRRect(image.Rect(x, 0, x+seg, size.Y), radius).Op(gtx.Ops))
+		gtx.Execute(op.InvalidateCmd{})
+		return layout.Dimensions{Size: size}
+	}
+
+	progress, buffer := clamp01(p.Progress), clamp01(p.Buffer)
+	if s := p.State; s != nil {
+		progress = kit.animate(gtx, &s.fill, progress)
+		buffer = kit.animate(gtx, &s.buffer, buffer)
+	}
+	if w := int(float32(size.X) * buffer); w > 0 {
+		paint.FillShape(gtx.Ops, withAlpha(fill, bufferAlpha), clip.UniformRRect(image.Rect(0, 0, w, size.Y), radius).Op(gtx.Ops))
+	}
+	if w := int(float32(size.X) * progress); w > 0 {
+		paint.FillShape(gtx.Ops, fill, clip.UniformRRect(image.Rect(0, 0, w, size.Y), radius).Op(gtx.Ops))
+	}
+	return layout.Dimensions{Size: size}
+}
+
+// CircularProgressStyle describes a ring that fills clockwise from the top,
+// or spins when indeterminate
+type CircularProgressStyle struct {
+	Progress      float32
+	Indeterminate bool
+	Variant       ProgressVariant
+	// Label is drawn centered inside the ring when not empty
+	Label     string
+	Size      unit.Dp
+	Thickness unit.Dp
+	// State animates changes to Progress when set
+	State *ProgressState
+
+	kit *UIKit
+}
+
+// CircularProgress creates a determinate progress ring
+func (kit *UIKit) CircularProgress(progress float32) layout.Widget {
+	return kit.CircularProgressStyle(progress).Layout
+}
+
+// Spinner creates an indeterminate progress ring
+func (kit *UIKit) Spinner() layout.Widget {
+	s := kit.CircularProgressStyle(0)
+	s.Indeterminate = true
+	return s.Layout
+}
+
+// CircularProgressStyle returns a progress ring that can be further configured before layout
+func (kit *UIKit) CircularProgressStyle(progress float32) CircularProgressStyle {
+	return CircularProgressStyle{
+		Progress:  progress,
+		Size:      unit.Dp(40),
+		Thickness: unit.Dp(4),
+		kit:       kit,
+	}
+}
+
+// Layout draws the ring
+func (c CircularProgressStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := c.kit
+	diameter := gtx.Dp(c.Size)
+	size := image.Pt(diameter, diameter)
+	width := float32(gtx.Dp(c.Thickness))
+	fill := kit.progressColor(c.Variant)
+
+	var start, sweep float32
+	if c.Indeterminate {
+		// The arc turns steadily while growing and shrinking
+		start = phase(gtx.Now, spinnerTurnPeriod) * 2 * math.Pi
+		grow := float32(math.Sin(float64(phase(gtx.Now, spinnerSweepPeriod)) * 2 * math.Pi))
+		sweep = (0.45 + 0.3*grow) * 2 * math.Pi
+		gtx.Execute(op.InvalidateCmd{})
+	} else {
+		progress := clamp01(c.Progress)
+		if c.State != nil {
+			progress = kit.animate(gtx, &c.State.fill, progress)
+		}
+		start = -math.Pi / 2
+		sweep = progress * 2 * math.Pi
+		strokeArc(gtx.Ops, size, width, 0, 2*math.Pi, kit.Colors.Gray200)
+	}
+	if sweep > 0 {
+		strokeArc(gtx.Ops, size, width, start, sweep, fill)
+	}
+
+	if c.Label != "" {
+		gtx.Constraints = layout.Exact(size)
+		layout.Center.Layout(gtx, kit.Label(c.Label, kit.Typography.LabelSmall, kit.Colors.TextSecondary).Layout)
+	}
+	return layout.Dimensions{Size: size}
+}
+
+// SegmentedProgressStyle describes progress through a fixed number of
+// steps, drawn as separate segments
+type SegmentedProgressStyle struct {
+	Steps     int
+	Completed int
+	Variant   ProgressVariant
+	// Label is drawn to the right of the segments when not empty
+	Label     string
+	Thickness unit.Dp
+
+	kit *UIKit
+}
+
+// StepProgress creates a segmented bar with completed of steps filled
+func (kit *UIKit) StepProgress(completed, steps int) layout.Widget {
+	return kit.SegmentedProgressStyle(completed, steps).Layout
+}
+
+// SegmentedProgressStyle returns a segmented bar that can be further configured before layout
+func (kit *UIKit) SegmentedProgressStyle(completed, steps int) SegmentedProgressStyle {
+	return SegmentedProgressStyle{
+		Steps:     steps,
+		Completed: completed,
+		Thickness: kit.Spacing.Small,
+		kit:       kit,
+	}
+}
+
+// Layout draws the segments across the available width
+func (s SegmentedProgressStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := s.kit
+	bar := func(gtx layout.Context) layout.Dimensions {
+		size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(s.Thickness))
+		if s.Steps <= 0 {
+			return layout.Dimensions{Size: size}
+		}
+		gap := gtx.Dp(kit.Spacing.Tiny)
+		seg := float32(size.X-gap*(s.Steps-1)) / float32(s.Steps)
+		fill := kit.progressColor(s.Variant)
+		for i := 0; i < s.Steps; i++ {
+			x0 := int(float32(i) * (seg + float32(gap)))
+			x1 := int(float32(i)*(seg+float32(gap)) + seg)
+			col := kit.Colors.Gray200
+			if i < s.Completed {
+				col = fill
+			}
+			paint.FillShape(gtx.Ops, col, clip.UniformRRect(image.Rect(x0, 0, x1, size.Y), size.Y/2).Op(gtx.Ops))
+		}
+		return layout.Dimensions{Size: size}
+	}
+	if s.Label == "" {
+		return bar(gtx)
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, bar),
+		layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout),
+		layout.Rigid(kit.Label(s.Label, kit.Typography.LabelSmall, kit.Colors.TextSecondary).Layout),
+	)
+}
+
+func (kit *UIKit) progressColor(v ProgressVariant) color.NRGBA {
+	switch v {
+	case ProgressSuccess:
+		return kit.Colors.Success
+	case ProgressWarning:
+		return kit.Colors.Warning
+	case ProgressError:
+		return kit.Colors.Error
+	default:
+		return kit.Colors.Primary500
+	}
+}
+
+// strokeArc strokes an arc of the circle inscribed in size, starting at
+// angle start (radians, clockwise from the positive x axis)
+func strokeArc(ops *op.Ops, size image.Point, width, start, sweep float32, col color.NRGBA) {
+	r := (float32(size.X) - width) / 2
+	center := f32.Pt(float32(size.X)/2, float32(size.Y)/2)
+	from := center.Add(f32.Pt(r*cos(start), r*sin(start)))
+
+	var p clip.Path
+	p.Begin(ops)
+	p.MoveTo(from)
+	p.ArcTo(center, center, sweep)
+	paint.FillShape(ops, col, clip.Stroke{Path: p.End(), Width: width}.Op())
+}
+
+// phase returns how far now is through a repeating period, from 0 to 1
+func phase(now time.Time, period time.Duration) float32 {
+	return float32(now.UnixNano()%int64(period)) / float32(period)
+}
+
+func clamp01(v float32) float32 {
+	return max(0, min(1, v))
+}
+
+func cos(a float32) float32 { return float32(math.Cos(float64(a))) }
+func sin(a float32) float32 { return float32(math.Sin(float64(a))) }
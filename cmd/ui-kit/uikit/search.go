@@ -0,0 +1,94 @@
+package uikit
+
+import (
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+// DefaultSearchDebounce is how long typing must pause before a search
+// runs, when Debounce is zero
+const DefaultSearchDebounce = 300 * time.Millisecond
+
+// SearchInput holds the text of a search input and runs its searches
+type SearchInput struct {
+	widget.Editor
+	// OnSearch is called on the UI goroutine with the query once typing
+	// pauses, and at once when Enter is pressed or the input is cleared
+	OnSearch func(query string)
+	Debounce time.Duration
+
+	clear   widget.Clickable
+	query   string
+	text    string
+	changed time.Time
+}
+
+// Query returns the query last searched for
+func (s *SearchInput) Query() string {
+	return s.query
+}
+
+func (s *SearchInput) search(query string) {
+	s.query = query
+	if s.OnSearch != nil {
+		s.OnSearch(query)
+	}
+}
+
+// SearchInputStyle is an input with a search icon and a clear button that
+// reports its query after a pause in typing
+type SearchInputStyle struct {
+	InputStyle
+	State *SearchInput
+}
+
+// SearchInput is a search input with the given hint
+func (kit *UIKit) SearchInput(state *SearchInput, hint string) layout.Widget {
+	return kit.SearchInputStyle(state, hint).Layout
+}
+
+// SearchInputStyle returns a search input that can be further configured
+// before layout
+func (kit *UIKit) SearchInputStyle(state *SearchInput, hint string) SearchInputStyle {
+	in := kit.InputStyle(&state.Editor, hint)
+	in.PrefixIcon = kit.Icons.Get(IconSearch)
+	in.Clear = &state.clear
+	return SearchInputStyle{InputStyle: in, State: state}
+}
+
+// Layout draws the input and runs a search when one is due
+func (p SearchInputStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := p.State
+	s.SingleLine = true
+	s.Submit = true
+	for {
+		ev, ok := s.Editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			s.search(s.Text())
+		}
+	}
+	dims := p.InputStyle.Layout(gtx)
+
+	text := s.Text()
+	if text != s.text {
+		s.text, s.changed = text, gtx.Now
+	}
+	if text != s.query {
+		debounce := s.Debounce
+		if debounce == 0 {
+			debounce = DefaultSearchDebounce
+		}
+		if due := s.changed.Add(debounce); text == "" || !gtx.Now.Before(due) {
+			s.search(text)
+		} else {
+			gtx.Execute(op.InvalidateCmd{At: due})
+		}
+	}
+	return dims
+}
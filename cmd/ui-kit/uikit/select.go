@@ -0,0 +1,666 @@
+package uikit
+
+import (
+	"image"
+	"slices"
+	"strings"
+	"time"
+
+	"gioui.org/gesture"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"uikit/uikit/anim"
+)
+
+const (
+	selectMenuMaxHeight = unit.Dp(280)
+	// selectTypeAhead is how long typed letters keep adding to one search
+	selectTypeAhead = time.Second
+)
+
+// SelectOption is one choice of a Select
+type SelectOption struct {
+	Key   string
+	Label string
+	// Group is shown as a heading above the first of a run of options
+	// sharing it
+	Group    string
+	Disabled bool
+}
+
+// SelectOptions returns options keyed by their labels
+func SelectOptions(labels ...string) []SelectOption {
+	options := make([]SelectOption, len(labels))
+	for i, l := range labels {
+		options[i] = SelectOption{Key: l, Label: l}
+	}
+	return options
+}
+
+// Select holds the choice of a select, or the choices of a multi-select,
+// and the state of its menu
+type Select struct {
+	// Value is the key of the chosen option, or empty
+	Value string
+	// Values are the keys chosen in a multi-select, in the order chosen
+	Values []string
+
+	// As of the last layout
+	options  []SelectOption
+	multiple bool
+	disabled bool
+	window   *windowState
+
+	open      bool
+	highlight int // Option the keyboard is on, or -1
+	reveal    bool
+	changed   bool
+	typed     string
+	typedAt   time.Time
+	anchor    popupAnchor
+	clicks    []gesture.Click    // Per option
+	chips     []widget.Clickable // Remove buttons, per value
+	scrim     gesture.Click
+	list      layout.List
+	shown     anim.Tween
+}
+
+// Update handles input since the last call and reports whether the user
+// changed the selection. While the field is focused, arrow keys, Enter and
+// Space open the menu; in the menu they move and choose, Escape closes it,
+// and typing jumps to the option starting with the typed letters.
+func (s *Select) Update(gtx layout.Context) bool {
+	if s.disabled {
+		s.open = false
+		return false
+	}
+	chipPressed := false
+	for i := range s.chips {
+		if s.chips[i].Clicked(gtx) && i < len(s.Values) {
+			s.Values = slices.Delete(s.Values, i, i+1)
+			s.changed = true
+			break
+		}
+		chipPressed = chipPressed || s.chips[i].Pressed()
+	}
+
+	s.anchor.locate(gtx, s.window)
+	for {
+		ev, ok := gtx.Event(pointer.Filter{Target: s, Kinds: pointer.Press})
+		if !ok {
+			break
+		}
+		e, ok := ev.(pointer.Event)
+		if !ok || e.Kind != pointer.Press || chipPressed {
+			continue
+		}
+		if e.Source == pointer.Mouse && !e.Buttons.Contain(pointer.ButtonPrimary) {
+			continue
+		}
+		gtx.Execute(key.FocusCmd{Tag: s})
+		s.setOpen(!s.open)
+	}
+	for {
+		e, ok := s.scrim.Update(gtx.Source)
+		if !ok {
+			break
+		}
+		if e.Kind == gesture.KindPress {
+			s.open = false
+		}
+	}
+	for i := range s.clicks {
+		for {
+			e, ok := s.clicks[i].Update(gtx.Source)
+			if !ok {
+				break
+			}
+			if e.Kind == gesture.KindClick && s.open {
+				s.pick(i)
+			}
+		}
+	}
+
+	filters := []event.Filter{
+		key.FocusFilter{Target: s},
+		key.Filter{Focus: s, Name: key.NameUpArrow},
+		key.Filter{Focus: s, Name: key.NameDownArrow},
+		key.Filter{Focus: s, Name: key.NameReturn},
+		key.Filter{Focus: s, Name: key.NameEnter},
+		key.Filter{Focus: s, Name: key.NameSpace},
+	}
+	if s.open {
+		filters = append(filters,
+			key.Filter{Focus: s, Name: key.NameEscape},
+			key.Filter{Focus: s, Name: key.NameHome},
+			key.Filter{Focus: s, Name: key.NameEnd},
+		)
+	}
+	if s.multiple && len(s.Values) > 0 {
+		filters = append(filters, key.Filter{Focus: s, Name: key.NameDeleteBackward})
+	}
+	for {
+		ev, ok := gtx.Event(filters...)
+		if !ok {
+			break
+		}
+		switch e := ev.(type) {
+		case key.FocusEvent:
+			if !e.Focus {
+				s.open = false
+			}
+		case key.EditEvent:
+			s.typeAhead(gtx, e.Text)
+		case key.Event:
+			if e.State != key.Press {
+				break
+			}
+			s.key(e.Name)
+		}
+	}
+
+	changed := s.changed
+	s.changed = false
+	return changed
+}
+
+// key handles a key press on the focused field
+func (s *Select) key(name key.Name) {
+	switch name {
+	case key.NameEscape:
+		s.open = false
+	case key.NameDeleteBackward:
+		if n := len(s.Values); n > 0 {
+			s.Values = s.Values[:n-1]
+			s.changed = true
+		}
+	case key.NameHome:
+		s.moveTo(s.next(-1, 1))
+	case key.NameEnd:
+		s.moveTo(s.next(len(s.options), -1))
+	case key.NameUpArrow, key.NameDownArrow:
+		if !s.open {
+			s.setOpen(true)
+		} else if name == key.NameUpArrow {
+			s.moveTo(s.next(s.highlight, -1))
+		} else {
+			s.moveTo(s.next(s.highlight, 1))
+		}
+	default:
+		if !s.open {
+			s.setOpen(true)
+		} else {
+			s.pick(s.highlight)
+		}
+	}
+}
+
+// typeAhead moves to the next option whose label starts with the letters
+// typed within selectTypeAhead of each other. Typing one letter over and
+// over cycles through the options starting with it. With the menu closed,
+// a single select chooses the option straight away.
+func (s *Select) typeAhead(gtx layout.Context, text string) {
+	if gtx.Now.Sub(s.typedAt) > selectTypeAhead {
+		s.typed = ""
+	}
+	if s.typed == "" && strings.TrimSpace(text) == "" {
+		return
+	}
+	s.typed += strings.ToLower(text)
+	s.typedAt = gtx.Now
+
+	start := s.highlight
+	if !s.open {
+		start = s.index(s.Value)
+	}
+	prefix := s.typed
+	if r := []rune(prefix); strings.Count(prefix, string(r[0])) == len(r) {
+		prefix = string(r[0])
+		start++
+	}
+	n := len(s.options)
+	for k := range n {
+		i := ((start+k)%n + n) % n
+		if s.enabled(i) && strings.HasPrefix(strings.ToLower(s.options[i].Label), prefix) {
+			if s.open || s.multiple {
+				s.setOpen(true)
+				s.moveTo(i)
+			} else {
+				s.pick(i)
+			}
+			return
+		}
+	}
+}
+
+func (s *Select) setOpen(open bool) {
+	if open && !s.open {
+		s.shown.Set(0)
+		s.highlight = -1
+		if s.multiple && len(s.Values) > 0 {
+			s.highlight = s.index(s.Values[0])
+		} else if !s.multiple {
+			s.highlight = s.index(s.Value)
+		}
+		if !s.enabled(s.highlight) {
+			s.highlight = s.next(-1, 1)
+		}
+		s.reveal = true
+	}
+	s.open = open
+}
+
+// pick chooses option i: a single select takes it and closes, a
+// multi-select adds or removes it and stays open
+func (s *Select) pick(i int) {
+	if !s.enabled(i) {
+		return
+	}
+	key := s.options[i].Key
+	s.highlight = i
+	if !s.multiple {
+		s.open = false
+		if s.Value != key {
+			s.Value, s.changed = key, true
+		}
+		return
+	}
+	if j := slices.Index(s.Values, key); j >= 0 {
+		s.Values = slices.Delete(s.Values, j, j+1)
+	} else {
+		s.Values = append(s.Values, key)
+	}
+	s.changed = true
+}
+
+func (s *Select) moveTo(i int) {
+	if s.enabled(i) {
+		s.highlight, s.reveal = i, true
+	}
+}
+
+// next returns the first enabled option after from in direction dir, or
+// from if there is none
+func (s *Select) next(from, dir int) int {
+	for i := from + dir; i >= 0 && i < len(s.options); i += dir {
+		if s.enabled(i) {
+			return i
+		}
+	}
+	return from
+}
+
+func (s *Select) enabled(i int) bool {
+	return i >= 0 && i < len(s.options) && !s.options[i].Disabled
+}
+
+// index returns the option with the key, or -1
+func (s *Select) index(key string) int {
+	return slices.IndexFunc(s.options, func(o SelectOption) bool { return o.Key == key })
+}
+
+func (s *Select) selected(key string) bool {
+	if s.multiple {
+		return slices.Contains(s.Values, key)
+	}
+	return s.Value == key
+}
+
+// SelectStyle is a field that opens a menu of options below it, or above
+// it near the bottom of the window. The menu stays inside the window when
+// the app lays out kit.TrackWindow; see there for how the field is placed
+// in the window before the pointer has moved.
+type SelectStyle struct {
+	InputStyle
+	State   *Select
+	Options []SelectOption
+	// Multiple lets the user choose several options, shown as removable
+	// chips in the field
+	Multiple bool
+}
+
+// Select is a select with a floating label
+func (kit *UIKit) Select(state *Select, label string, options ...SelectOption) layout.Widget {
+	return kit.SelectStyle(state, label, options...).Layout
+}
+
+// SelectStyle returns a select that can be further configured before
+// layout
+func (kit *UIKit) SelectStyle(state *Select, label string, options ...SelectOption) SelectStyle {
+	in := kit.InputStyle(nil, "")
+	in.Label = label
+	in.LabelPlacement = InputLabelFloating
+	return SelectStyle{InputStyle: in, State: state, Options: options}
+}
+
+// Layout draws the field, and the menu while it is open
+func (sel SelectStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := sel.State
+	s.options, s.multiple, s.window = sel.Options, sel.Multiple, &sel.kit.window
+	s.disabled = sel.Disabled || sel.ReadOnly
+	s.anchor.avail = gtx.Constraints.Max.Y
+	if n := len(sel.Options); len(s.clicks) < n {
+		s.clicks = append(s.clicks, make([]gesture.Click, n-len(s.clicks))...)
+	}
+	if n := len(s.Values); len(s.chips) < n {
+		s.chips = append(s.chips, make([]widget.Clickable, n-len(s.chips))...)
+	}
+	s.Update(gtx)
+
+	in := sel.InputStyle
+	in.Editor = nil
+	in.Clear = nil
+	in.focus = s
+	in.filled = s.Value != "" && !sel.Multiple || len(s.Values) > 0 && sel.Multiple
+	in.content = sel.layoutValue
+	in.Trailing = sel.layoutArrow
+	in.overlay = sel.layoutOverlay
+	return in.Layout(gtx)
+}
+
+// layoutValue draws the chosen option's label, the chips of a
+// multi-select, or the hint
+func (sel SelectStyle) layoutValue(gtx layout.Context) layout.Dimensions {
+	s := sel.State
+	kit := sel.kit
+	typo := kit.Typography.BodyLarge
+	if sel.Multiple && len(s.Values) > 0 {
+		return sel.layoutChips(gtx)
+	}
+	text, col := "", kit.Colors.OnSurface
+	if !sel.Multiple && s.Value != "" {
+		text = s.Value
+		if i := s.index(s.Value); i >= 0 {
+			text = sel.Options[i].Label
+		}
+	}
+	floating := sel.Label != "" && sel.LabelPlacement == InputLabelFloating
+	if text == "" && (!floating || gtx.Focused(s)) {
+		text, col = sel.Hint, sel.secondary()
+	}
+	if sel.Disabled {
+		col = kit.Colors.TextDisabled
+	}
+	if text == "" {
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, gtx.Sp(typo.LineHeight))}
+	}
+	l := kit.Label(text, typo, col)
+	l.MaxLines = 1
+	return l.Layout(gtx)
+}
+
+// layoutChips lays out the chosen options of a multi-select as chips,
+// wrapping onto further rows
+func (sel SelectStyle) layoutChips(gtx layout.Context) layout.Dimensions {
+	s := sel.State
+	gap := gtx.Dp(sel.kit.Spacing.Tiny)
+	width := gtx.Constraints.Max.X
+	cgtx := gtx
+	cgtx.Constraints.Min = image.Point{}
+	var x, y, rowHeight int
+	for i, key := range s.Values {
+		label := key
+		if j := s.index(key); j >= 0 {
+			label = sel.Options[j].Label
+		}
+		macro := op.Record(gtx.Ops)
+		dims := sel.layoutChip(cgtx, &s.chips[i], label)
+		call := macro.Stop()
+		if x > 0 && x+dims.Size.X > width {
+			x, y, rowHeight = 0, y+rowHeight+gap, 0
+		}
+		off := op.Offset(image.Pt(x, y)).Push(gtx.Ops)
+		call.Add(gtx.Ops)
+		off.Pop()
+		x += dims.Size.X + gap
+		rowHeight = max(rowHeight, dims.Size.Y)
+	}
+	return layout.Dimensions{Size: image.Pt(width, y+rowHeight)}
+}
+
+// layoutChip draws a chosen option as a pill one line of body text high,
+// with a button to remove it
+func (sel SelectStyle) layoutChip(gtx layout.Context, remove *widget.Clickable, label string) layout.Dimensions {
+	kit := sel.kit
+	h := gtx.Sp(kit.Typography.BodyLarge.LineHeight)
+	gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = h, h
+	bg, fg := kit.Colors.Primary100, kit.Colors.Primary900
+	if !gtx.Enabled() {
+		bg, fg = kit.Colors.Gray200, kit.Colors.TextDisabled
+	}
+
+	macro := op.Record(gtx.Ops)
+	inset := layout.Inset{Left: kit.Spacing.Small, Right: kit.Spacing.Small}
+	if gtx.Enabled() {
+		inset.Right = kit.Spacing.Tiny
+	}
+	dims := inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				l := kit.Label(label, kit.Typography.LabelMedium, fg)
+				l.MaxLines = 1
+				return l.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if !gtx.Enabled() {
+					return layout.Dimensions{}
+				}
+				size := gtx.Sp(kit.Typography.LabelMedium.LineHeight)
+				return layout.Inset{Left: kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return remove.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						semantic.Button.Add(gtx.Ops)
+						semantic.DescriptionOp("Remove " + label).Add(gtx.Ops)
+						dims := layoutIcon(gtx, kit.Icons.Get(IconClose), size, fg)
+						defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+						pointer.CursorPointer.Add(gtx.Ops)
+						return dims
+					})
+				})
+			}),
+		)
+	})
+	call := macro.Stop()
+
+	rect := image.Rectangle{Max: dims.Size}
+	paint.FillShape(gtx.Ops, bg, clip.UniformRRect(rect, dims.Size.Y/2).Op(gtx.Ops))
+	if gtx.Focused(remove) {
+		kit.strokeRRect(gtx, dims.Size, gtx.Metric.PxToDp(dims.Size.Y/2), unit.Dp(2), kit.Colors.Focus)
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
+
+// layoutArrow draws the icon showing whether the menu is open
+func (sel SelectStyle) layoutArrow(gtx layout.Context) layout.Dimensions {
+	kit := sel.kit
+	icon, col := IconExpandMore, sel.secondary()
+	if sel.State.open {
+		icon, col = IconExpandLess, kit.Colors.Primary500
+	}
+	if sel.Disabled {
+		col = kit.Colors.TextDisabled
+	}
+	return layoutIcon(gtx, kit.Icons.Get(icon), gtx.Sp(kit.Typography.BodyLarge.LineHeight), col)
+}
+
+// layoutOverlay takes presses on the field and hangs the menu from it. The
+// field's area passes presses through to the chips' remove buttons.
+func (sel SelectStyle) layoutOverlay(gtx layout.Context) layout.Dimensions {
+	s := sel.State
+	size := gtx.Constraints.Min
+	s.anchor.size = size
+	if gtx.Enabled() && !sel.ReadOnly {
+		s.anchor.track(gtx)
+		pass := pointer.PassOp{}.Push(gtx.Ops)
+		area := clip.Rect{Max: size}.Push(gtx.Ops)
+		semantic.Button.Add(gtx.Ops)
+		semantic.LabelOp(sel.Label).Add(gtx.Ops)
+		event.Op(gtx.Ops, s)
+		pointer.CursorPointer.Add(gtx.Ops)
+		area.Pop()
+		pass.Pop()
+	}
+	if s.open {
+		kit := sel.kit
+		s.shown.Duration = kit.Motion.Duration(kit.Motion.Fast)
+		s.shown.Easing = anim.EaseOut
+		s.shown.To(1)
+		kit.layoutPopup(gtx, s.anchor, &s.scrim, false, sel.layoutMenu)
+	}
+	return layout.Dimensions{Size: size}
+}
+
+// selectRow is a row of the menu: an option, or the heading of a group
+type selectRow struct {
+	option int // Index into the options, or -1 for a heading
+	group  string
+}
+
+// layoutMenu draws the open menu, scrolling when it is taller than the
+// room for it
+func (sel SelectStyle) layoutMenu(gtx layout.Context) layout.Dimensions {
+	s := sel.State
+	kit := sel.kit
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(selectMenuMaxHeight))
+	defer paint.PushOpacity(gtx.Ops, s.shown.Value(gtx)).Pop()
+
+	var rows []selectRow
+	group := ""
+	for i, o := range sel.Options {
+		if o.Group != group && o.Group != "" {
+			rows = append(rows, selectRow{option: -1, group: o.Group})
+		}
+		group = o.Group
+		rows = append(rows, selectRow{option: i})
+	}
+	if s.reveal {
+		s.reveal = false
+		revealRow(&s.list, slices.IndexFunc(rows, func(r selectRow) bool { return r.option == s.highlight }))
+	}
+
+	radius := kit.Radius.Medium
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			kit.PaintShadow(gtx, size, radius, Elevation2)
+			defer clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(radius)).Push(gtx.Ops).Pop()
+			// Keep presses on the menu's padding from reaching the scrim
+			event.Op(gtx.Ops, &s.list)
+			paint.Fill(gtx.Ops, kit.Colors.SurfaceElevated)
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: kit.Spacing.Tiny, Bottom: kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				s.list.Axis = layout.Vertical
+				return s.list.Layout(gtx, len(rows), func(gtx layout.Context, i int) layout.Dimensions {
+					if r := rows[i]; r.option >= 0 {
+						return sel.layoutOption(gtx, r.option)
+					}
+					return layout.Inset{
+						Top: kit.Spacing.Small, Bottom: kit.Spacing.Tiny,
+						Left: kit.Spacing.Medium, Right: kit.Spacing.Medium,
+					}.Layout(gtx, kit.Label(rows[i].group, kit.Typography.LabelMedium, kit.Colors.TextSecondary).Layout)
+				})
+			})
+		}),
+	)
+}
+
+// revealRow scrolls a list so row is in view, leaving the scroll where it
+// is if it already is
+func revealRow(l *layout.List, row int) {
+	pos := &l.Position
+	switch {
+	case row < 0:
+	case pos.Count == 0 || row <= pos.First:
+		pos.First, pos.Offset = row, 0
+	case row >= pos.First+pos.Count-1:
+		// The last visible row may be cut off, so bring it fully in
+		pos.First, pos.Offset = row-max(pos.Count-2, 0), 0
+	}
+}
+
+// layoutOption draws an option row, with a check mark on chosen options
+// of a multi-select
+func (sel SelectStyle) layoutOption(gtx layout.Context, i int) layout.Dimensions {
+	s := sel.State
+	kit := sel.kit
+	o := sel.Options[i]
+	selected := s.selected(o.Key)
+	typo := kit.Typography.BodyLarge
+	iconSize := gtx.Sp(typo.LineHeight)
+	col := kit.Colors.OnSurface
+	switch {
+	case o.Disabled:
+		col = kit.Colors.TextDisabled
+	case selected && !sel.Multiple:
+		col = kit.Colors.Primary500
+	}
+
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	macro := op.Record(gtx.Ops)
+	dims := layout.Inset{
+		Top: kit.Spacing.Small, Bottom: kit.Spacing.Small,
+		Left: kit.Spacing.Medium, Right: kit.Spacing.Medium,
+	}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if !sel.Multiple {
+					return layout.Dimensions{}
+				}
+				size := image.Pt(iconSize, iconSize)
+				if selected {
+					layoutIcon(gtx, kit.Icons.Get(IconCheck), iconSize, kit.Colors.Primary500)
+				}
+				return layout.Inset{Right: kit.Spacing.Small}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Dimensions{Size: size}
+				})
+			}),
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return kit.Label(o.Label, typo, col).Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if sel.Multiple || !selected {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Left: kit.Spacing.Small}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layoutIcon(gtx, kit.Icons.Get(IconCheck), iconSize, kit.Colors.Primary500)
+				})
+			}),
+		)
+	})
+	call := macro.Stop()
+
+	rect := image.Rectangle{Max: dims.Size}
+	if selected && !sel.Multiple {
+		paint.FillShape(gtx.Ops, withAlpha(kit.Colors.Primary500, hoverLayerAlpha), clip.Rect(rect).Op())
+	}
+	if !o.Disabled && (i == s.highlight || s.clicks[i].Hovered()) {
+		alpha := uint8(hoverLayerAlpha)
+		if i == s.highlight {
+			alpha = focusLayerAlpha
+		}
+		paint.FillShape(gtx.Ops, withAlpha(kit.Colors.OnSurface, alpha), clip.Rect(rect).Op())
+	}
+	call.Add(gtx.Ops)
+
+	defer clip.Rect(rect).Push(gtx.Ops).Pop()
+	semantic.LabelOp(o.Label).Add(gtx.Ops)
+	semantic.SelectedOp(selected).Add(gtx.Ops)
+	semantic.EnabledOp(!o.Disabled).Add(gtx.Ops)
+	if !o.Disabled {
+		s.clicks[i].Add(gtx.Ops)
+		pointer.CursorPointer.Add(gtx.Ops)
+	}
+	return dims
+}
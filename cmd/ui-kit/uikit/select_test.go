@@ -0,0 +1,53 @@
+package uikit
+
+import (
+	"testing"
+	"time"
+
+	"gioui.org/io/key"
+	"gioui.org/layout"
+)
+
+func TestSelectKeys(t *testing.T) {
+	s := &Select{options: []SelectOption{
+		{Key: "a", Label: "Apple"},
+		{Key: "b", Label: "Banana", Disabled: true},
+		{Key: "c", Label: "Cherry"},
+	}}
+	s.key(key.NameDownArrow)
+	if !s.open || s.highlight != 0 {
+		t.Fatalf("down opened = %v at %d; want open at 0", s.open, s.highlight)
+	}
+	s.key(key.NameDownArrow)
+	if s.highlight != 2 {
+		t.Errorf("down moved to %d; want 2, past the disabled option", s.highlight)
+	}
+	s.key(key.NameReturn)
+	if s.open || s.Value != "c" || !s.changed {
+		t.Errorf("enter left open = %v, value = %q, changed = %v", s.open, s.Value, s.changed)
+	}
+}
+
+func TestSelectTypeAhead(t *testing.T) {
+	s := &Select{highlight: -1, options: SelectOptions("Berlin", "Boston", "Lisbon", "Bordeaux")}
+	gtx := layout.Context{Now: time.Unix(100, 0)}
+	for _, want := range []string{"Berlin", "Boston", "Bordeaux", "Berlin"} {
+		s.typeAhead(gtx, "b")
+		if s.Value != want {
+			t.Errorf("repeated b chose %q; want %q", s.Value, want)
+		}
+		gtx.Now = gtx.Now.Add(100 * time.Millisecond)
+	}
+	gtx.Now = gtx.Now.Add(2 * selectTypeAhead)
+	for _, r := range "bor" {
+		s.typeAhead(gtx, string(r))
+	}
+	if s.Value != "Bordeaux" {
+		t.Errorf("typing bor chose %q", s.Value)
+	}
+	gtx.Now = gtx.Now.Add(2 * selectTypeAhead)
+	s.typeAhead(gtx, "l")
+	if s.Value != "Lisbon" {
+		t.Errorf("l after a pause chose %q", s.Value)
+	}
+}
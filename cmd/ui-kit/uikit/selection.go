@@ -0,0 +1,438 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// Sizes of the selection controls
+const (
+	selectionBoxSize   = unit.Dp(18) // Checkbox square and radio circle
+	selectionLayerSize = unit.Dp(40) // Round state layer around them
+	switchWidth        = unit.Dp(52) // Switch track with room for the layer
+	switchHeight       = unit.Dp(32)
+	switchTrackWidth   = unit.Dp(44)
+	switchTrackHeight  = unit.Dp(24)
+)
+
+// CheckboxStyle describes a checkbox. The widget.Bool holds the checked
+// state and toggles on click, Space and Enter.
+type CheckboxStyle struct {
+	Label string
+	// Helper is shown under the label while there is no Error
+	Helper string
+	Error  string
+	// Indeterminate shows a dash in place of the check, for a checkbox that
+	// stands for a partly checked group. Clicks still toggle the Bool; the
+	// caller decides what they do to the group.
+	Indeterminate bool
+	// Disabled dims the checkbox and stops it from receiving clicks or focus
+	Disabled bool
+	Bool     *widget.Bool
+
+	kit *UIKit
+}
+
+// Checkbox creates a labeled checkbox
+func (kit *UIKit) Checkbox(b *widget.Bool, label string) layout.Widget {
+	return kit.CheckboxStyle(b, label).Layout
+}
+
+// CheckboxStyle returns a checkbox that can be further configured before
+// layout
+func (kit *UIKit) CheckboxStyle(b *widget.Bool, label string) CheckboxStyle {
+	return CheckboxStyle{Label: label, Bool: b, kit: kit}
+}
+
+// Layout draws the checkbox and its label. Like buttons, disabled
+// checkboxes are laid out without their Bool.
+func (c CheckboxStyle) Layout(gtx layout.Context) layout.Dimensions {
+	if c.Disabled {
+		return c.layout(gtx.Disabled())
+	}
+	return c.Bool.Layout(gtx, c.layout)
+}
+
+func (c CheckboxStyle) layout(gtx layout.Context) layout.Dimensions {
+	kit := c.kit
+	semantic.CheckBox.Add(gtx.Ops)
+	semantic.LabelOp(c.Label).Add(gtx.Ops)
+	enabled := gtx.Enabled()
+	focused := enabled && gtx.Focused(c.Bool)
+	level := kit.selectionLevel(gtx, c.Bool, enabled && c.Bool.Hovered(), enabled && c.Bool.Pressed(), focused)
+	checked := c.Bool.Value || c.Indeterminate
+	accent, outline := kit.selectionColors(c.Error != "", c.Disabled)
+
+	control := func(gtx layout.Context) layout.Dimensions {
+		layer := kit.Colors.OnSurface
+		if checked {
+			layer = accent
+		}
+		return kit.layoutSelectionControl(gtx, level, focused, layer, func(gtx layout.Context) {
+			s := gtx.Dp(selectionBoxSize)
+			box := image.Rectangle{Max: image.Pt(s, s)}
+			if !checked {
+				kit.strokeRRect(gtx, box.Max, unit.Dp(2), unit.Dp(2), outline)
+				return
+			}
+			paint.FillShape(gtx.Ops, accent, clip.UniformRRect(box, gtx.Dp(2)).Op(gtx.Ops))
+			mark := kit.Colors.OnPrimary
+			if c.Disabled {
+				mark = kit.Colors.Surface
+			}
+			pt := func(x, y float32) f32.Point { return f32.Pt(x*float32(s), y*float32(s)) }
+			var p clip.Path
+			p.Begin(gtx.Ops)
+			if c.Indeterminate {
+				p.MoveTo(pt(0.25, 0.5))
+				p.LineTo(pt(0.75, 0.5))
+			} else {
+				p.MoveTo(pt(0.22, 0.52))
+				p.LineTo(pt(0.42, 0.72))
+				p.LineTo(pt(0.78, 0.32))
+			}
+			paint.FillShape(gtx.Ops, mark, clip.Stroke{Path: p.End(), Width: float32(gtx.Dp(2))}.Op())
+		})
+	}
+	return kit.layoutSelectionRow(gtx, control, c.Label, c.Helper, c.Error, c.Disabled)
+}
+
+// RadioOption is one choice of a radio group
+type RadioOption struct {
+	Key   string
+	Label string
+	// Helper is shown under the option's label
+	Helper   string
+	Disabled bool
+}
+
+// RadioOptions returns options whose keys are their labels
+func RadioOptions(labels ...string) []RadioOption {
+	options := make([]RadioOption, len(labels))
+	for i, l := range labels {
+		options[i] = RadioOption{Key: l, Label: l}
+	}
+	return options
+}
+
+// RadioGroupStyle describes a set of radio buttons. The widget.Enum holds
+// the selected key; an option is selected on click, Space and Enter, and
+// Tab moves between options.
+type RadioGroupStyle struct {
+	// Label names the group above its options
+	Label   string
+	Options []RadioOption
+	// Axis the options are laid out along
+	Axis layout.Axis
+	// Helper is shown under the group while there is no Error
+	Helper   string
+	Error    string
+	Disabled bool
+	Enum     *widget.Enum
+
+	kit *UIKit
+}
+
+// radioKey keys the transition of one option of a group
+type radioKey struct {
+	enum *widget.Enum
+	key  string
+}
+
+// RadioGroup creates a vertical radio group
+func (kit *UIKit) RadioGroup(enum *widget.Enum, options ...RadioOption) layout.Widget {
+	return kit.RadioGroupStyle(enum, options...).Layout
+}
+
+// RadioGroupStyle returns a vertical radio group that can be further
+// configured before layout
+func (kit *UIKit) RadioGroupStyle(enum *widget.Enum, options ...RadioOption) RadioGroupStyle {
+	return RadioGroupStyle{Options: options, Axis: layout.Vertical, Enum: enum, kit: kit}
+}
+
+// Layout draws the group label, the options and the helper or error text
+func (r RadioGroupStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := r.kit
+	if r.Disabled {
+		gtx = gtx.Disabled()
+	}
+	labelColor := kit.Colors.TextPrimary
+	if r.Disabled {
+		labelColor = kit.Colors.TextDisabled
+	}
+
+	options := make([]layout.FlexChild, 0, 2*len(r.Options))
+	for i, o := range r.Options {
+		if i > 0 && r.Axis == layout.Horizontal {
+			options = append(options, layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout))
+		}
+		options = append(options, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return r.layoutOption(gtx, o)
+		}))
+	}
+
+	var children []layout.FlexChild
+	if r.Label != "" {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: kit.Spacing.Tiny}.Layout(gtx,
+				kit.Label(r.Label, kit.Typography.LabelMedium, labelColor).Layout)
+		}))
+	}
+	children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: r.Axis}.Layout(gtx, options...)
+	}))
+	if text, col := kit.supportingText(r.Helper, r.Error, r.Disabled); text != "" {
+		children = append(children, layout.Rigid(kit.Label(text, kit.Typography.LabelSmall, col).Layout))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (r RadioGroupStyle) layoutOption(gtx layout.Context, o RadioOption) layout.Dimensions {
+	disabled := r.Disabled || o.Disabled
+	content := func(gtx layout.Context) layout.Dimensions {
+		kit := r.kit
+		semantic.RadioButton.Add(gtx.Ops)
+		semantic.LabelOp(o.Label).Add(gtx.Ops)
+		hovered, focused := false, false
+		if gtx.Enabled() {
+			k, ok := r.Enum.Hovered()
+			hovered = ok && k == o.Key
+			k, ok = r.Enum.Focused()
+			focused = ok && k == o.Key
+		}
+		level := kit.selectionLevel(gtx, radioKey{r.Enum, o.Key}, hovered, false, focused)
+		selected := r.Enum.Value == o.Key
+		accent, outline := kit.selectionColors(r.Error != "", disabled)
+
+		control := func(gtx layout.Context) layout.Dimensions {
+			layer, ring := kit.Colors.OnSurface, outline
+			if selected {
+				layer, ring = accent, accent
+			}
+			return kit.layoutSelectionControl(gtx, level, focused, layer, func(gtx layout.Context) {
+				s := gtx.Dp(selectionBoxSize)
+				w := gtx.Dp(2)
+				outer := image.Rect(w/2, w/2, s-w/2, s-w/2)
+				paint.FillShape(gtx.Ops, ring, clip.Stroke{Path: clip.Ellipse(outer).Path(gtx.Ops), Width: float32(w)}.Op())
+				if selected {
+					d := s / 4
+					paint.FillShape(gtx.Ops, accent, clip.Ellipse(image.Rect(d, d, s-d, s-d)).Op(gtx.Ops))
+				}
+			})
+		}
+		return kit.layoutSelectionRow(gtx, control, o.Label, o.Helper, "", disabled)
+	}
+	if disabled {
+		return content(gtx.Disabled())
+	}
+	return r.Enum.Layout(gtx, o.Key, content)
+}
+
+// SwitchStyle describes an on/off switch. The widget.Bool holds the state
+// and toggles on click, Space and Enter.
+type SwitchStyle struct {
+	Label string
+	// Helper is shown under the label while there is no Error
+	Helper   string
+	Error    string
+	Disabled bool
+	Bool     *widget.Bool
+
+	kit *UIKit
+}
+
+// Switch creates a labeled switch
+func (kit *UIKit) Switch(b *widget.Bool, label string) layout.Widget {
+	return kit.SwitchStyle(b, label).Layout
+}
+
+// SwitchStyle returns a switch that can be further configured before
+// layout
+func (kit *UIKit) SwitchStyle(b *widget.Bool, label string) SwitchStyle {
+	return SwitchStyle{Label: label, Bool: b, kit: kit}
+}
+
+// Layout draws the switch and its label
+func (s SwitchStyle) Layout(gtx layout.Context) layout.Dimensions {
+	if s.Disabled {
+		return s.layout(gtx.Disabled())
+	}
+	return s.Bool.Layout(gtx, s.layout)
+}
+
+func (s SwitchStyle) layout(gtx layout.Context) layout.Dimensions {
+	kit := s.kit
+	semantic.Switch.Add(gtx.Ops)
+	semantic.LabelOp(s.Label).Add(gtx.Ops)
+	enabled := gtx.Enabled()
+	focused := enabled && gtx.Focused(s.Bool)
+	level := kit.selectionLevel(gtx, s.Bool, enabled && s.Bool.Hovered(), enabled && s.Bool.Pressed(), focused)
+
+	// The thumb slides between off (0) and on (1). Its transition is keyed
+	// by the Value field, as the Bool itself keys the state layer's.
+	var target float32
+	if s.Bool.Value {
+		target = 1
+	}
+	tw := kit.tween(gtx, &s.Bool.Value)
+	tw.Duration = kit.Motion.Duration(kit.Motion.Fast)
+	tw.To(target)
+	on := tw.Value(gtx)
+
+	c := kit.Colors
+	offTrack, onTrack := c.Gray200, c.Primary500
+	offThumb, onThumb := c.OnSurfaceVariant, c.OnPrimary
+	switch {
+	case s.Disabled:
+		onTrack, offThumb, onThumb = c.TextDisabled, c.TextDisabled, c.Surface
+	case s.Error != "":
+		onTrack, offThumb = c.Error, c.Error
+	}
+	track := mix(offTrack, onTrack, float64(on))
+	thumb := mix(offThumb, onThumb, float64(on))
+
+	control := func(gtx layout.Context) layout.Dimensions {
+		size := image.Pt(gtx.Dp(switchWidth), gtx.Dp(switchHeight))
+		tw, th := gtx.Dp(switchTrackWidth), gtx.Dp(switchTrackHeight)
+		tr := image.Rectangle{Max: image.Pt(tw, th)}.Add(size.Sub(image.Pt(tw, th)).Div(2))
+		paint.FillShape(gtx.Ops, track, clip.UniformRRect(tr, th/2).Op(gtx.Ops))
+		if on < 1 {
+			// The off track's outline fades out as it fills
+			border := withAlpha(offThumb, uint8(255*(1-on)))
+			t := op.Offset(tr.Min).Push(gtx.Ops)
+			kit.strokeRRect(gtx, tr.Size(), gtx.Metric.PxToDp(th/2), unit.Dp(2), border)
+			t.Pop()
+		}
+
+		// The thumb grows when on and again while pressed
+		d := lerp(float32(gtx.Dp(16)), float32(gtx.Dp(20)), on)
+		if level > 1 {
+			d = lerp(d, float32(gtx.Dp(24)), level-1)
+		}
+		cx := lerp(float32(tr.Min.X+th/2), float32(tr.Max.X-th/2), on)
+		cy := float32(size.Y) / 2
+		layerCol := c.OnSurface
+		if s.Bool.Value {
+			layerCol = onTrack
+		}
+		ls := size.Y
+		t := op.Offset(image.Pt(int(cx)-ls/2, 0)).Push(gtx.Ops)
+		kit.paintStateLayer(gtx, ls, level, focused, layerCol)
+		t.Pop()
+		thumbRect := image.Rect(int(cx-d/2), int(cy-d/2), int(cx+d/2), int(cy+d/2))
+		paint.FillShape(gtx.Ops, thumb, clip.Ellipse(thumbRect).Op(gtx.Ops))
+		return layout.Dimensions{Size: size}
+	}
+	return kit.layoutSelectionRow(gtx, control, s.Label, s.Helper, s.Error, s.Disabled)
+}
+
+// selectionLevel eases a control between rest (0), hover or focus (1) and
+// pressed (2)
+func (kit *UIKit) selectionLevel(gtx layout.Context, key any, hovered, pressed, focused bool) float32 {
+	var level float32
+	switch {
+	case pressed:
+		level = 2
+	case hovered, focused:
+		level = 1
+	}
+	tw := kit.tween(gtx, key)
+	tw.Duration = kit.Motion.Duration(kit.Motion.Fast)
+	tw.To(level)
+	return tw.Value(gtx)
+}
+
+// selectionColors returns the color of a selected control and the outline
+// of an unselected one
+func (kit *UIKit) selectionColors(hasError, disabled bool) (accent, outline color.NRGBA) {
+	c := kit.Colors
+	switch {
+	case disabled:
+		return c.TextDisabled, c.TextDisabled
+	case hasError:
+		return c.Error, c.Error
+	}
+	return c.Primary500, c.OnSurfaceVariant
+}
+
+// layoutSelectionControl draws a checkbox or radio indicator centered in
+// its state layer
+func (kit *UIKit) layoutSelectionControl(gtx layout.Context, level float32, focused bool, layer color.NRGBA, indicator func(gtx layout.Context)) layout.Dimensions {
+	size := gtx.Dp(selectionLayerSize)
+	kit.paintStateLayer(gtx, size, level, focused, layer)
+	off := (size - gtx.Dp(selectionBoxSize)) / 2
+	defer op.Offset(image.Pt(off, off)).Push(gtx.Ops).Pop()
+	indicator(gtx)
+	return layout.Dimensions{Size: image.Pt(size, size)}
+}
+
+// paintStateLayer fills a size×size circle with the state layer for level,
+// and rings it while focused
+func (kit *UIKit) paintStateLayer(gtx layout.Context, size int, level float32, focused bool, col color.NRGBA) {
+	hover := float32(hoverLayerAlpha)
+	if focused {
+		hover = focusLayerAlpha
+	}
+	var alpha uint8
+	if level <= 1 {
+		alpha = uint8(hover * level)
+	} else {
+		alpha = uint8(hover + (pressedLayerAlpha-hover)*(level-1))
+	}
+	rect := image.Rectangle{Max: image.Pt(size, size)}
+	if alpha > 0 {
+		paint.FillShape(gtx.Ops, withAlpha(col, alpha), clip.Ellipse(rect).Op(gtx.Ops))
+	}
+	if focused {
+		kit.strokeRRect(gtx, rect.Max, gtx.Metric.PxToDp(size/2), unit.Dp(2), kit.Colors.Focus)
+	}
+}
+
+// layoutSelectionRow lays out a control followed by its label, with the
+// helper or error text under the label
+func (kit *UIKit) layoutSelectionRow(gtx layout.Context, control layout.Widget, label, helper, errText string, disabled bool) layout.Dimensions {
+	labelColor := kit.Colors.TextPrimary
+	if disabled {
+		labelColor = kit.Colors.TextDisabled
+	}
+	text, col := kit.supportingText(helper, errText, disabled)
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(control),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if label == "" && text == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Left: kit.Spacing.Tiny, Right: kit.Spacing.Small}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(kit.Label(label, kit.Typography.BodyMedium, labelColor).Layout),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if text == "" {
+							return layout.Dimensions{}
+						}
+						return kit.Label(text, kit.Typography.LabelSmall, col).Layout(gtx)
+					}),
+				)
+			})
+		}),
+	)
+}
+
+// supportingText returns the error, or else the helper, and its color
+func (kit *UIKit) supportingText(helper, errText string, disabled bool) (string, color.NRGBA) {
+	switch {
+	case disabled:
+		return helper, kit.Colors.TextDisabled
+	case errText != "":
+		return errText, kit.Colors.Error
+	}
+	return helper, kit.Colors.TextSecondary
+}
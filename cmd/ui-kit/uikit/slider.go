@@ -0,0 +1,389 @@
+package uikit
+
+import (
+	"image"
+	"math"
+	"strconv"
+
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// Sizes of a slider
+const (
+	sliderThumbSize   = unit.Dp(20)
+	sliderLayerSize   = unit.Dp(40) // State layer around a thumb; also the slider's thickness
+	sliderTrackHeight = unit.Dp(4)
+	sliderTickSize    = unit.Dp(2)
+	// sliderLength is the length of a vertical slider given no minimum height
+	sliderLength = unit.Dp(200)
+	// sliderMaxTicks is the most tick marks drawn; finer steps draw none
+	sliderMaxTicks = 100
+)
+
+// Slider holds the value of a slider, or both ends of a range slider, and
+// the drag in progress
+type Slider struct {
+	// Value is the slider's value, or the low end of a range
+	Value float32
+	// High is the high end of a range
+	High float32
+
+	drag     gesture.Drag
+	thumbs   [2]sliderThumb
+	dragging int  // 1 + the index of the dragged thumb, or 0
+	keyboard bool // The last change came from the keyboard
+
+	// Settings and geometry of the last layout, for Update
+	min, max, step float32
+	isRange        bool
+	axis           layout.Axis
+	start, length  int // Where the track runs, in pixels along the axis
+}
+
+// sliderThumb is the focus target of one thumb
+type sliderThumb struct {
+	focused bool
+}
+
+// Update handles drags and keys since the last call and reports whether
+// the user changed a value
+func (s *Slider) Update(gtx layout.Context) bool {
+	if s.length <= 0 || s.max <= s.min {
+		return false
+	}
+	changed := false
+	axis := gesture.Horizontal
+	if s.axis == layout.Vertical {
+		axis = gesture.Vertical
+	}
+	for {
+		ev, ok := s.drag.Update(gtx.Metric, gtx.Source, axis)
+		if !ok {
+			break
+		}
+		switch ev.Kind {
+		case pointer.Press:
+			v := s.valueAt(ev.Position)
+			thumb := 0
+			if s.isRange && (v > s.High || v-s.Value > s.High-v) {
+				thumb = 1
+			}
+			s.dragging, s.keyboard = thumb+1, false
+			gtx.Execute(key.FocusCmd{Tag: &s.thumbs[thumb]})
+			changed = s.set(thumb, v) || changed
+		case pointer.Drag:
+			if s.dragging > 0 {
+				changed = s.set(s.dragging-1, s.valueAt(ev.Position)) || changed
+			}
+		case pointer.Release, pointer.Cancel:
+			s.dragging = 0
+		}
+	}
+
+	step := s.step
+	if step <= 0 {
+		step = (s.max - s.min) / 100
+	}
+	page := max(step, (s.max-s.min)/10)
+	for i := range s.thumbs {
+		t := &s.thumbs[i]
+		for {
+			ev, ok := gtx.Event(
+				key.FocusFilter{Target: t},
+				key.Filter{Focus: t, Name: key.NameLeftArrow},
+				key.Filter{Focus: t, Name: key.NameRightArrow},
+				key.Filter{Focus: t, Name: key.NameUpArrow},
+				key.Filter{Focus: t, Name: key.NameDownArrow},
+				key.Filter{Focus: t, Name: key.NamePageUp},
+				key.Filter{Focus: t, Name: key.NamePageDown},
+				key.Filter{Focus: t, Name: key.NameHome},
+				key.Filter{Focus: t, Name: key.NameEnd},
+			)
+			if !ok {
+				break
+			}
+			switch ev := ev.(type) {
+			case key.FocusEvent:
+				t.focused = ev.Focus
+			case key.Event:
+				if ev.State != key.Press {
+					break
+				}
+				v := s.value(i)
+				switch ev.Name {
+				case key.NameLeftArrow, key.NameDownArrow:
+					v -= step
+				case key.NameRightArrow, key.NameUpArrow:
+					v += step
+				case key.NamePageDown:
+					v -= page
+				case key.NamePageUp:
+					v += page
+				case key.NameHome:
+					v = s.min
+				case key.NameEnd:
+					v = s.max
+				}
+				s.keyboard = true
+				changed = s.set(i, v) || changed
+			}
+		}
+	}
+	return changed
+}
+
+func (s *Slider) value(thumb int) float32 {
+	if thumb == 1 {
+		return s.High
+	}
+	return s.Value
+}
+
+// set moves a thumb to v, snapped to the steps and kept within range and on
+// its side of the other thumb, and reports whether it moved
+func (s *Slider) set(thumb int, v float32) bool {
+	if s.step > 0 {
+		v = s.min + float32(math.Round(float64((v-s.min)/s.step)))*s.step
+	}
+	v = max(s.min, min(s.max, v))
+	old := s.value(thumb)
+	switch {
+	case thumb == 1:
+		s.High = max(v, s.Value)
+	case s.isRange:
+		s.Value = min(v, s.High)
+	default:
+		s.Value = v
+	}
+	return s.value(thumb) != old
+}
+
+// valueAt returns the value under a point of the slider
+func (s *Slider) valueAt(p f32.Point) float32 {
+	along := p.X
+	if s.axis == layout.Vertical {
+		along = p.Y
+	}
+	frac := (along - float32(s.start)) / float32(s.length)
+	if s.axis == layout.Vertical {
+		frac = 1 - frac
+	}
+	return s.min + max(0, min(1, frac))*(s.max-s.min)
+}
+
+// pos returns where v lies along the slider, in pixels
+func (s *Slider) pos(v float32) int {
+	frac := (v - s.min) / (s.max - s.min)
+	if s.axis == layout.Vertical {
+		frac = 1 - frac
+	}
+	return s.start + int(frac*float32(s.length)+0.5)
+}
+
+// SliderStyle describes a slider. Arrow keys move the focused thumb by a
+// step, Page Up and Page Down by a tenth of the range, Home and End to the
+// ends; Tab moves between the thumbs of a range.
+type SliderStyle struct {
+	Min, Max float32
+	// Step snaps values to multiples of it from Min and draws tick marks;
+	// zero slides freely
+	Step float32
+	// Range selects Value to High with two thumbs
+	Range bool
+	// Axis is Horizontal, or Vertical with Min at the bottom
+	Axis layout.Axis
+	// Format writes a value for the tooltip shown while a thumb is dragged
+	// or moved with the keyboard; nil writes as many decimals as Step has
+	Format   func(v float32) string
+	Disabled bool
+	State    *Slider
+
+	kit *UIKit
+}
+
+// Slider creates a horizontal slider
+func (kit *UIKit) Slider(state *Slider, min, max float32) layout.Widget {
+	return kit.SliderStyle(state, min, max).Layout
+}
+
+// SliderStyle returns a horizontal slider that can be further configured
+// before layout
+func (kit *UIKit) SliderStyle(state *Slider, min, max float32) SliderStyle {
+	return SliderStyle{Min: min, Max: max, State: state, kit: kit}
+}
+
+// Layout draws the slider across the available width, or for a vertical
+// slider down the minimum height
+func (sl SliderStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := sl.State
+	kit := sl.kit
+	s.min, s.max, s.step, s.isRange, s.axis = sl.Min, max(sl.Max, sl.Min), sl.Step, sl.Range, sl.Axis
+	if sl.Disabled {
+		gtx = gtx.Disabled()
+		s.dragging = 0
+	}
+
+	thick := gtx.Dp(sliderLayerSize)
+	size := image.Pt(gtx.Constraints.Max.X, thick)
+	if sl.Axis == layout.Vertical {
+		length := gtx.Constraints.Min.Y
+		if length == 0 {
+			length = min(gtx.Dp(sliderLength), gtx.Constraints.Max.Y)
+		}
+		size = image.Pt(thick, length)
+	}
+	size = gtx.Constraints.Constrain(size)
+	s.start = thick / 2
+	s.length = max(sl.Axis.Convert(size).X-thick, 1)
+	s.Update(gtx)
+	s.set(0, s.Value)
+	if sl.Range {
+		s.set(1, s.High)
+	}
+
+	// point returns the center of the slider at pos along it
+	point := func(pos int) image.Point {
+		if sl.Axis == layout.Vertical {
+			return image.Pt(size.X/2, pos)
+		}
+		return image.Pt(pos, size.Y/2)
+	}
+	// span returns a rectangle of the given thickness between two positions
+	span := func(a, b, thickness int) image.Rectangle {
+		pa, pb := point(a), point(b)
+		r := image.Rectangle{Min: pa, Max: pb}.Canon()
+		if sl.Axis == layout.Vertical {
+			r.Min.X, r.Max.X = size.X/2-thickness/2, size.X/2+thickness-thickness/2
+		} else {
+			r.Min.Y, r.Max.Y = size.Y/2-thickness/2, size.Y/2+thickness-thickness/2
+		}
+		return r
+	}
+
+	accent, outline := kit.selectionColors(false, sl.Disabled)
+	low, high := s.min, s.Value
+	if sl.Range {
+		low, high = s.Value, s.High
+	}
+	track := gtx.Dp(sliderTrackHeight)
+	end := s.start + s.length
+	paint.FillShape(gtx.Ops, kit.Colors.Gray200, clip.UniformRRect(span(s.start, end, track), track/2).Op(gtx.Ops))
+	paint.FillShape(gtx.Ops, accent, clip.UniformRRect(span(s.pos(low), s.pos(high), track), track/2).Op(gtx.Ops))
+
+	if n := sl.ticks(); n > 0 && s.length/n >= gtx.Dp(8) {
+		tick := gtx.Dp(sliderTickSize)
+		for k := 0; k <= n; k++ {
+			v := s.min + float32(k)*s.step
+			col := outline
+			if v >= low && v <= high {
+				col = withAlpha(kit.Colors.OnPrimary, 0xB3)
+			}
+			c := point(s.pos(v))
+			r := image.Rectangle{Min: c, Max: c.Add(image.Pt(tick, tick))}.Sub(image.Pt(tick/2, tick/2))
+			paint.FillShape(gtx.Ops, col, clip.Ellipse(r).Op(gtx.Ops))
+		}
+	}
+
+	thumbs := 1
+	if sl.Range {
+		thumbs = 2
+	}
+	for i := 0; i < thumbs; i++ {
+		t := &s.thumbs[i]
+		focused := gtx.Enabled() && t.focused
+		dragged := s.dragging == i+1
+		level := kit.selectionLevel(gtx, t, false, dragged, focused)
+		c := point(s.pos(s.value(i)))
+
+		layer := image.Pt(thick/2, thick/2)
+		off := op.Offset(c.Sub(layer)).Push(gtx.Ops)
+		kit.paintStateLayer(gtx, thick, level, focused, accent)
+		area := clip.Rect{Max: image.Pt(thick, thick)}.Push(gtx.Ops)
+		if gtx.Enabled() {
+			event.Op(gtx.Ops, t)
+		}
+		area.Pop()
+		off.Pop()
+
+		d := gtx.Dp(sliderThumbSize)
+		thumb := image.Rectangle{Min: c, Max: c.Add(image.Pt(d, d))}.Sub(image.Pt(d/2, d/2))
+		paint.FillShape(gtx.Ops, accent, clip.Ellipse(thumb).Op(gtx.Ops))
+
+		if dragged || focused && s.keyboard {
+			sl.layoutTooltip(gtx, c, s.value(i))
+		}
+	}
+
+	// The drag area lies over the thumbs so presses anywhere move one
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	if gtx.Enabled() {
+		s.drag.Add(gtx.Ops)
+		pointer.CursorPointer.Add(gtx.Ops)
+	}
+	return layout.Dimensions{Size: size}
+}
+
+// ticks returns the number of steps to draw tick marks between, or zero
+func (sl SliderStyle) ticks() int {
+	if sl.Step <= 0 || sl.Max <= sl.Min {
+		return 0
+	}
+	// Whole steps only, so no tick lies past Max; the slack absorbs
+	// rounding in steps such as 0.1
+	n := int(math.Floor(float64((sl.Max-sl.Min)/sl.Step) + 1e-4))
+	if n > sliderMaxTicks {
+		return 0
+	}
+	return n
+}
+
+// layoutTooltip draws the value in a bubble beside the thumb centered at c:
+// above a horizontal slider, left of a vertical one
+func (sl SliderStyle) layoutTooltip(gtx layout.Context, c image.Point, v float32) {
+	kit := sl.kit
+	text := ""
+	if sl.Format != nil {
+		text = sl.Format(v)
+	} else {
+		text = strconv.FormatFloat(float64(v), 'f', sl.decimals(), 32)
+	}
+
+	macro := op.Record(gtx.Ops)
+	lgtx := gtx
+	lgtx.Constraints.Min = image.Point{}
+	dims := layout.Inset{
+		Top: kit.Spacing.Tiny, Bottom: kit.Spacing.Tiny,
+		Left: kit.Spacing.Small, Right: kit.Spacing.Small,
+	}.Layout(lgtx, kit.Label(text, kit.Typography.LabelMedium, kit.Colors.OnPrimary).Layout)
+	call := macro.Stop()
+
+	gap := gtx.Dp(sliderLayerSize)/2 + gtx.Dp(kit.Spacing.Tiny)
+	at := image.Pt(c.X-dims.Size.X/2, c.Y-gap-dims.Size.Y)
+	if sl.Axis == layout.Vertical {
+		at = image.Pt(c.X-gap-dims.Size.X, c.Y-dims.Size.Y/2)
+	}
+	defer op.Offset(at).Push(gtx.Ops).Pop()
+	bubble := clip.UniformRRect(image.Rectangle{Max: dims.Size}, gtx.Dp(kit.Radius.Small))
+	paint.FillShape(gtx.Ops, kit.Colors.Primary500, bubble.Op(gtx.Ops))
+	call.Add(gtx.Ops)
+}
+
+// decimals returns how many decimals the tooltip shows
+func (sl SliderStyle) decimals() int {
+	switch {
+	case sl.Step > 0:
+		return stepDecimals(float64(sl.Step), 32)
+	case sl.Max-sl.Min <= 1:
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package uikit
+
+import (
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+)
+
+func TestSliderSet(t *testing.T) {
+	s := &Slider{min: 0, max: 10, step: 2.5, isRange: true, High: 5}
+	if !s.set(0, 3.9) || s.Value != 5 {
+		t.Errorf("snapped value = %v, want 5", s.Value)
+	}
+	s.set(0, 9)
+	if s.Value != 5 {
+		t.Errorf("low end passed the high end: %v", s.Value)
+	}
+	s.set(1, 20)
+	if s.High != 10 {
+		t.Errorf("high end = %v, want 10", s.High)
+	}
+	if s.set(1, 9.9) {
+		t.Error("set reported a change that snapped back to the same value")
+	}
+}
+
+func TestSliderValueAt(t *testing.T) {
+	s := &Slider{min: 0, max: 100, start: 10, length: 200, axis: layout.Vertical}
+	if v := s.valueAt(f32.Pt(0, 10)); v != 100 {
+		t.Errorf("top of vertical slider = %v, want 100", v)
+	}
+	if v := s.valueAt(f32.Pt(0, 160)); v != 25 {
+		t.Errorf("valueAt = %v, want 25", v)
+	}
+	if p := s.pos(25); p != 160 {
+		t.Errorf("pos(25) = %v, want 160", p)
+	}
+}
+
+func TestSliderTicksAndDecimals(t *testing.T) {
+	tests := []struct {
+		min, max, step float32
+		ticks          int
+		decimals       int
+	}{
+		{0, 10, 4, 2, 0},
+		{0, 10, 2.5, 4, 1},
+		{0, 1, 0.1, 10, 1},
+		{0, 1, 0.05, 20, 2},
+		{0, 100, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		sl := SliderStyle{Min: tt.min, Max: tt.max, Step: tt.step}
+		if got := sl.ticks(); got != tt.ticks {
+			t.Errorf("%v to %v by %v: %d ticks; want %d", tt.min, tt.max, tt.step, got, tt.ticks)
+		}
+		if got := sl.decimals(); got != tt.decimals {
+			t.Errorf("%v to %v by %v: %d decimals; want %d", tt.min, tt.max, tt.step, got, tt.decimals)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/io/key"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// Tab styles
+type TabsVariant int
+
+const (
+	TabsUnderline TabsVariant = iota
+	TabsPill
+)
+
+// Tab is one entry in a tab bar
+type Tab struct {
+	Text string
+	Icon *Icon
+	// Badge is drawn after the text when not empty, e.g. an unread count
+	Badge        string
+	BadgeVariant BadgeVariant
+}
+
+// Tabs holds the persistent state of a tab bar: the selected index, one
+// Clickable per tab and the scroll position. Keep it alongside the other
+// widget state of the app.
+type Tabs struct {
+	Selected int
+
+	clicks  []*widget.Clickable
+	widths  []int // Of each tab when last laid out; zero if never
+	list    layout.List
+	changed bool
+	scroll  bool // Reveal the selected tab on the next layout
+}
+
+// Update processes clicks and arrow keys and reports whether the selection
+// changed since the last call. Tab switches the keyboard focus into the bar;
+// Left, Right, Home and End then move the selection.
+func (t *Tabs) Update(gtx layout.Context) bool {
+	changed := t.changed
+	t.changed = false
+	for i, c := range t.clicks {
+		if c.Clicked(gtx) && i != t.Selected {
+			t.Selected = i
+			changed = true
+		}
+		for {
+			ev, ok := gtx.Event(
+				key.Filter{Focus: c, Name: key.NameLeftArrow},
+				key.Filter{Focus: c, Name: key.NameRightArrow},
+				key.Filter{Focus: c, Name: key.NameHome},
+				key.Filter{Focus: c, Name: key.NameEnd},
+			)
+			if !ok {
+				break
+			}
+			e, ok := ev.(key.Event)
+			if !ok || e.State != key.Press {
+				continue
+			}
+			next := i
+			switch e.Name {
+			case key.NameLeftArrow:
+				next = (i + len(t.clicks) - 1) % len(t.clicks)
+			case key.NameRightArrow:
+				next = (i + 1) % len(t.clicks)
+			case key.NameHome:
+				next = 0
+			case key.NameEnd:
+				next = len(t.clicks) - 1
+			}
+			if next != t.Selected {
+				t.Selected = next
+				changed = true
+			}
+			gtx.Execute(key.FocusCmd{Tag: t.clicks[next]})
+			t.scroll = true
+		}
+	}
+	return changed
+}
+
+// Select makes tab i the selected one; it is reported by the next Update
+func (t *Tabs) Select(i int) {
+	if i != t.Selected {
+		t.Selected = i
+		t.changed = true
+		t.scroll = true
+	}
+}
+
+// reveal scrolls the bar just enough to show tab i, given the bar's width.
+// A tab past the end is lined up with the end of the bar, using the widths
+// the tabs had when last laid out; if some were never laid out, it is
+// scrolled to the start of the bar instead.
+func (t *Tabs) reveal(i, width int) {
+	pos := &t.list.Position
+	switch {
+	case i <= pos.First:
+		pos.First, pos.Offset = i, 0
+	case i > pos.First+pos.Count-1, i == pos.First+pos.Count-1 && pos.OffsetLast < 0:
+		pos.First, pos.Offset = i, 0
+		end := 0
+		for j := i; j >= 0 && j < len(t.widths); j-- {
+			if t.widths[j] == 0 {
+				return
+			}
+			end += t.widths[j]
+			if end >= width {
+				pos.First, pos.Offset = j, end-width
+				return
+			}
+		}
+		pos.First = 0 // Everything up to tab i fits
+	}
+}
+
+// TabsStyle draws a Tabs state with the given entries
+type TabsStyle struct {
+	Variant TabsVariant
+	Items   []Tab
+	State   *Tabs
+
+	kit *UIKit
+}
+
+// Tabs creates a tab bar; the selection lives in state
+func (kit *UIKit) Tabs(state *Tabs, variant TabsVariant, items ...Tab) layout.Widget {
+	return kit.TabsStyle(state, variant, items...).Layout
+}
+
+// TabsStyle returns a tab bar that can be further configured before layout
+func (kit *UIKit) TabsStyle(state *Tabs, variant TabsVariant, items ...Tab) TabsStyle {
+	return TabsStyle{
+		Variant: variant,
+		Items:   items,
+		State:   state,
+		kit:     kit,
+	}
+}
+
+// Layout draws the tab bar. When the tabs are wider than the available
+// space the bar scrolls horizontally.
+func (ts TabsStyle) Layout(gtx layout.Context) layout.Dimensions {
+	t := ts.State
+	for len(t.clicks) < len(ts.Items) {
+		t.clicks = append(t.clicks, new(widget.Clickable))
+	}
+	t.clicks = t.clicks[:len(ts.Items)]
+	for len(t.widths) < len(ts.Items) {
+		t.widths = append(t.widths, 0)
+	}
+	t.widths = t.widths[:len(ts.Items)]
+	if t.Selected >= len(ts.Items) {
+		t.Selected = len(ts.Items) - 1
+	}
+	if t.Selected < 0 {
+		t.Selected = 0
+	}
+	if t.Update(gtx) {
+		t.changed = true
+	}
+	if t.scroll {
+		t.scroll = false
+		t.reveal(t.Selected, gtx.Constraints.Max.X)
+	}
+
+	t.list.Axis = layout.Horizontal
+	t.list.Alignment = layout.Middle
+	return layout.Stack{Alignment: layout.SW}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			if ts.Variant != TabsUnderline {
+				return layout.Dimensions{}
+			}
+			// Baseline the selected tab's indicator sits on
+			size := gtx.Constraints.Min
+			line := image.Rect(0, size.Y-gtx.Dp(1), size.X, size.Y)
+			paint.FillShape(gtx.Ops, ts.kit.Colors.Border, clip.Rect(line).Op())
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min = image.Pt(gtx.Constraints.Max.X, 0)
+			return t.list.Layout(gtx, len(ts.Items), func(gtx layout.Context, i int) layout.Dimensions {
+				dims := ts.layoutTab(gtx, i)
+				t.widths[i] = dims.Size.X
+				return dims
+			})
+		}),
+	)
+}
+
+func (ts TabsStyle) layoutTab(gtx layout.Context, i int) layout.Dimensions {
+	kit := ts.kit
+	click := ts.State.clicks[i]
+	selected := i == ts.State.Selected
+	pill := ts.Variant == TabsPill
+
+	var gap layout.Inset
+	if pill && i < len(ts.Items)-1 {
+		gap.Right = kit.Spacing.Tiny
+	}
+	return gap.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return click.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			semantic.Button.Add(gtx.Ops)
+			semantic.SelectedOp(selected).Add(gtx.Ops)
+
+			fg := kit.Colors.TextSecondary
+			var bg color.NRGBA
+			switch {
+			case selected && pill:
+				fg, bg = kit.Colors.OnPrimary, kit.Colors.Primary500
+			case selected:
+				fg = kit.Colors.Primary500
+			}
+
+			return layout.Stack{}.Layout(gtx,
+				layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+					size := gtx.Constraints.Min
+					radius := kit.Radius.Small
+					if pill {
+						radius = gtx.Metric.PxToDp(size.Y / 2)
+					}
+					area := clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(radius))
+
+					if bg.A > 0 {
+						paint.FillShape(gtx.Ops, bg, area.Op(gtx.Ops))
+					}
+					if click.Hovered() && bg.A == 0 {
+						paint.FillShape(gtx.Ops, withAlpha(fg, hoverLayerAlpha), area.Op(gtx.Ops))
+					}
+					if selected && !pill {
+						indicator := image.Rect(0, size.Y-gtx.Dp(2), size.X, size.Y)
+						paint.FillShape(gtx.Ops, kit.Colors.Primary500, clip.Rect(indicator).Op())
+					}
+					if gtx.Focused(click) {
+						kit.strokeRRect(gtx, size, radius, unit.Dp(2), kit.Colors.Focus)
+					}
+					return layout.Dimensions{Size: size}
+				}),
+				layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{
+						Top: kit.Spacing.Small, Bottom: kit.Spacing.Small,
+						Left: kit.Spacing.Medium, Right: kit.Spacing.Medium,
+					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ts.layoutContent(gtx, ts.Items[i], fg)
+					})
+				}),
+			)
+		})
+	})
+}
+
+// layoutContent lays out a tab's icon, text and badge in a row
+func (ts TabsStyle) layoutContent(gtx layout.Context, tab Tab, fg color.NRGBA) layout.Dimensions {
+	kit := ts.kit
+	typo := kit.Typography.LabelLarge
+	gap := layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout)
+
+	var children []layout.FlexChild
+	if tab.Icon != nil {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layoutIcon(gtx, tab.Icon, gtx.Sp(typo.LineHeight), fg)
+		}))
+	}
+	if tab.Text != "" {
+		if len(children) > 0 {
+			children = append(children, gap)
+		}
+		children = append(children, layout.Rigid(kit.Label(tab.Text, typo, fg).Layout))
+	}
+	if tab.Badge != "" {
+		if len(children) > 0 {
+			children = append(children, gap)
+		}
+		children = append(children, layout.Rigid(kit.Badge(tab.Badge, tab.BadgeVariant)))
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
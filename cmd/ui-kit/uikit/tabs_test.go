@@ -0,0 +1,37 @@
+package uikit
+
+import (
+	"testing"
+
+	"gioui.org/layout"
+)
+
+func TestTabsReveal(t *testing.T) {
+	tests := []struct {
+		widths       []int
+		first, count int
+		tab          int
+		wantFirst    int
+		wantOffset   int
+	}{
+		// Tab 3 ends the bar, showing the end of tab 2
+		{[]int{100, 50, 80, 150}, 0, 2, 3, 2, 30},
+		{[]int{100, 50, 80, 120}, 0, 2, 3, 2, 0},
+		// Tabs of different widths: only the wide tab 3 fits
+		{[]int{60, 60, 60, 240, 60}, 0, 3, 3, 3, 40},
+		// Everything up to the tab fits
+		{[]int{50, 50, 50}, 0, 2, 2, 0, 0},
+		// Tab 2 was never laid out, so tab 3 starts the bar
+		{[]int{100, 50, 0, 150}, 0, 1, 3, 3, 0},
+		// A tab before the first visible one starts the bar
+		{[]int{100, 50, 80, 150}, 2, 2, 1, 1, 0},
+	}
+	for _, tt := range tests {
+		tabs := &Tabs{widths: tt.widths}
+		tabs.list.Position = layout.Position{First: tt.first, Count: tt.count}
+		tabs.reveal(tt.tab, 200)
+		if pos := tabs.list.Position; pos.First != tt.wantFirst || pos.Offset != tt.wantOffset {
+			t.Errorf("widths %v: reveal(%d) scrolled to %d+%d; want %d+%d", tt.widths, tt.tab, pos.First, pos.Offset, tt.wantFirst, tt.wantOffset)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package uikit
+
+import (
+	"image"
+
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// textAreaHandleSize is the size of the resize handle's drag area
+const textAreaHandleSize = unit.Dp(16)
+
+// TextArea holds the text of a multiline input and the height the user
+// dragged it to
+type TextArea struct {
+	widget.Editor
+	// Height is the field height set with the resize handle; zero grows the
+	// field with its content
+	Height unit.Dp
+
+	submitted   bool
+	drag        gesture.Drag
+	grab        float32 // Where the handle was grabbed, within it
+	fieldHeight int     // Laid out field height, in pixels
+}
+
+// Submitted reports whether Ctrl+Enter, or Cmd+Enter on macOS, was pressed
+// since the last call
+func (t *TextArea) Submitted(gtx layout.Context) bool {
+	t.update(gtx)
+	submitted := t.submitted
+	t.submitted = false
+	return submitted
+}
+
+// update collects submit shortcuts. The editor's own Enter handling does
+// not accept the shortcut modifier, so these never insert a line break.
+func (t *TextArea) update(gtx layout.Context) {
+	for {
+		ev, ok := gtx.Event(
+			key.Filter{Focus: &t.Editor, Name: key.NameReturn, Required: key.ModShortcut},
+			key.Filter{Focus: &t.Editor, Name: key.NameEnter, Required: key.ModShortcut},
+		)
+		if !ok {
+			break
+		}
+		if e, ok := ev.(key.Event); ok && e.State == key.Press {
+			t.submitted = true
+		}
+	}
+}
+
+// TextAreaStyle is a multiline input that grows with its content between
+// MinRows and MaxRows lines and scrolls beyond them
+type TextAreaStyle struct {
+	InputStyle
+	State   *TextArea
+	MinRows int
+	MaxRows int
+	// Resizable shows a handle in the corner to drag the height
+	Resizable bool
+}
+
+// TextArea is a labeled, resizable multiline input
+func (kit *UIKit) TextArea(state *TextArea, label, hint string) layout.Widget {
+	return kit.TextAreaStyle(state, label, hint).Layout
+}
+
+// TextAreaStyle returns a text area that can be further configured before
+// layout
+func (kit *UIKit) TextAreaStyle(state *TextArea, label, hint string) TextAreaStyle {
+	in := kit.InputStyle(&state.Editor, hint)
+	in.Label = label
+	return TextAreaStyle{
+		InputStyle: in,
+		State:      state,
+		MinRows:    3,
+		MaxRows:    8,
+		Resizable:  true,
+	}
+}
+
+// Layout draws the text area
+func (t TextAreaStyle) Layout(gtx layout.Context) layout.Dimensions {
+	s := t.State
+	s.SingleLine = false
+	s.Submit = false
+	s.update(gtx)
+
+	minRows := max(t.MinRows, 1)
+	t.minLines, t.maxLines = minRows, max(t.MaxRows, minRows)
+	if t.Resizable && !t.Disabled {
+		t.resize(gtx, minRows)
+		t.overlay = t.layoutHandle
+	}
+	if s.Height > 0 {
+		t.height = gtx.Dp(s.Height)
+	}
+	return t.InputStyle.Layout(gtx)
+}
+
+// resize follows drags of the handle. The handle moves with the bottom
+// edge, so the pointer's offset from the grab point is how far the field
+// has still to grow.
+func (t TextAreaStyle) resize(gtx layout.Context, minRows int) {
+	s := t.State
+	var moved *float32
+	for {
+		ev, ok := s.drag.Update(gtx.Metric, gtx.Source, gesture.Vertical)
+		if !ok {
+			break
+		}
+		switch ev.Kind {
+		case pointer.Press:
+			s.grab = ev.Position.Y
+		case pointer.Drag:
+			y := ev.Position.Y
+			moved = &y
+		}
+	}
+	if moved == nil || s.fieldHeight == 0 {
+		return
+	}
+	kit := t.kit
+	lowest := minRows*gtx.Sp(kit.Typography.BodyLarge.LineHeight) + 2*gtx.Dp(kit.Spacing.Medium)
+	h := max(s.fieldHeight+int(*moved-s.grab), lowest)
+	s.Height = gtx.Metric.PxToDp(h)
+}
+
+// layoutHandle draws the resize handle in the field's bottom right corner
+func (t TextAreaStyle) layoutHandle(gtx layout.Context) layout.Dimensions {
+	s := t.State
+	size := gtx.Constraints.Min
+	s.fieldHeight = size.Y
+	n := gtx.Dp(textAreaHandleSize)
+	defer op.Offset(size.Sub(image.Pt(n, n))).Push(gtx.Ops).Pop()
+
+	// Two diagonal grip lines
+	col := t.secondary()
+	if s.drag.Dragging() {
+		col = t.kit.Colors.Primary500
+	}
+	var p clip.Path
+	p.Begin(gtx.Ops)
+	inset := float32(gtx.Dp(4))
+	for _, l := range []float32{float32(n) / 2, inset} {
+		p.MoveTo(f32.Pt(l, float32(n)-inset))
+		p.LineTo(f32.Pt(float32(n)-inset, l))
+	}
+	paint.FillShape(gtx.Ops, col, clip.Stroke{Path: p.End(), Width: float32(gtx.Dp(1))}.Op())
+
+	defer clip.Rect{Max: image.Pt(n, n)}.Push(gtx.Ops).Pop()
+	s.drag.Add(gtx.Ops)
+	pointer.CursorNorthSouthResize.Add(gtx.Ops)
+	return layout.Dimensions{Size: image.Pt(n, n)}
+}
@@ -0,0 +1,311 @@
+package uikit
+
+import (
+	"image"
+	"image/color"
+	"sync"
+	"time"
+
+	"gioui.org/gesture"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// Corners and edges toasts can stack in
+type ToastPosition int
+
+const (
+	ToastBottomRight ToastPosition = iota
+	ToastBottomLeft
+	ToastBottomCenter
+	ToastTopRight
+	ToastTopLeft
+	ToastTopCenter
+)
+
+const (
+	// DefaultToastDuration is how long a toast stays up when Duration is zero
+	DefaultToastDuration = 4 * time.Second
+	// defaultMaxToasts is the number of toasts shown at once when MaxVisible is zero
+	defaultMaxToasts = 3
+	toastMaxWidth    = unit.Dp(360)
+)
+
+// ToastID identifies a pushed toast
+type ToastID uint64
+
+// Toast is a short notification
+type Toast struct {
+	Message string
+	Variant AlertVariant
+	// Action labels an optional button, such as "Undo"; clicks are reported
+	// by Toaster.Update
+	Action string
+	// Duration before the toast is dismissed; zero means
+	// DefaultToastDuration and a negative value keeps it until closed
+	Duration time.Duration
+}
+
+// Toaster queues toasts and shows a few at a time in one corner of the
+// window. Push and Dismiss are safe to call from any goroutine; everything
+// else belongs to the UI goroutine.
+type Toaster struct {
+	Position ToastPosition
+	// MaxVisible limits the toasts on screen; the rest wait their turn
+	MaxVisible int
+	// Invalidate is called after Push or Dismiss from another goroutine to
+	// wake the UI, usually set to the app window's Invalidate method
+	Invalidate func()
+
+	mu        sync.Mutex
+	lastID    ToastID
+	incoming  []*toastState
+	dismissed []ToastID
+
+	toasts  []*toastState
+	actions []ToastID
+}
+
+type toastState struct {
+	Toast
+	id        ToastID
+	remaining time.Duration
+	last      time.Time // Frame time the countdown was last advanced
+	hover     gesture.Hover
+	action    widget.Clickable
+	close     widget.Clickable
+}
+
+// Push queues a toast and returns its id
+func (t *Toaster) Push(toast Toast) ToastID {
+	t.mu.Lock()
+	t.lastID++
+	id := t.lastID
+	remaining := toast.Duration
+	if remaining == 0 {
+		remaining = DefaultToastDuration
+	}
+	t.incoming = append(t.incoming, &toastState{Toast: toast, id: id, remaining: remaining})
+	invalidate := t.Invalidate
+	t.mu.Unlock()
+
+	if invalidate != nil {
+		invalidate()
+	}
+	return id
+}
+
+// Dismiss removes a toast, whether shown or still queued
+func (t *Toaster) Dismiss(id ToastID) {
+	t.mu.Lock()
+	t.dismissed = append(t.dismissed, id)
+	invalidate := t.Invalidate
+	t.mu.Unlock()
+
+	if invalidate != nil {
+		invalidate()
+	}
+}
+
+// Update returns the id of the next toast whose action was clicked.
+// Clicking an action also dismisses its toast.
+func (t *Toaster) Update(gtx layout.Context) (ToastID, bool) {
+	t.update(gtx)
+	if len(t.actions) == 0 {
+		return 0, false
+	}
+	id := t.actions[0]
+	t.actions = t.actions[1:]
+	return id, true
+}
+
+// update takes in pushed and dismissed toasts and handles button clicks
+func (t *Toaster) update(gtx layout.Context) {
+	t.mu.Lock()
+	t.toasts = append(t.toasts, t.incoming...)
+	t.incoming = t.incoming[:0]
+	dismissed := t.dismissed
+	t.dismissed = nil
+	t.mu.Unlock()
+
+	for _, id := range dismissed {
+		t.remove(id)
+	}
+	for _, ts := range t.visible() {
+		if ts.action.Clicked(gtx) {
+			t.actions = append(t.actions, ts.id)
+			t.remove(ts.id)
+		}
+		if ts.close.Clicked(gtx) {
+			t.remove(ts.id)
+		}
+	}
+}
+
+func (t *Toaster) remove(id ToastID) {
+	for i, ts := range t.toasts {
+		if ts.id == id {
+			t.toasts = append(t.toasts[:i], t.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// visible returns a copy of the toasts currently on screen, oldest first
+func (t *Toaster) visible() []*toastState {
+	n := t.MaxVisible
+	if n <= 0 {
+		n = defaultMaxToasts
+	}
+	return append([]*toastState(nil), t.toasts[:min(n, len(t.toasts))]...)
+}
+
+// countdown advances the timers of the visible toasts, removes expired ones
+// and schedules a redraw for the next expiry. Hovered toasts are paused.
+func (t *Toaster) countdown(gtx layout.Context) {
+	var next time.Duration
+	for _, ts := range t.visible() {
+		if ts.hover.Update(gtx.Source) || ts.Duration < 0 {
+			ts.last = time.Time{}
+			continue
+		}
+		if !ts.last.IsZero() {
+			ts.remaining -= gtx.Now.Sub(ts.last)
+		}
+		ts.last = gtx.Now
+		if ts.remaining <= 0 {
+			t.remove(ts.id)
+			// The next toast in the queue moves up; time it from the next frame
+			gtx.Execute(op.InvalidateCmd{})
+			continue
+		}
+		if next == 0 || ts.remaining < next {
+			next = ts.remaining
+		}
+	}
+	if next > 0 {
+		gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(next)})
+	}
+}
+
+// Toasts draws the toaster's visible toasts above everything laid out
+// before it. Call it last, with the constraints of the whole window.
+func (kit *UIKit) Toasts(t *Toaster) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		t.update(gtx)
+		t.countdown(gtx)
+		toasts := t.visible()
+		if len(toasts) == 0 {
+			return layout.Dimensions{}
+		}
+
+		top := t.Position == ToastTopRight || t.Position == ToastTopLeft || t.Position == ToastTopCenter
+		var direction layout.Direction
+		alignment := layout.End
+		switch t.Position {
+		case ToastBottomRight:
+			direction = layout.SE
+		case ToastBottomLeft:
+			direction, alignment = layout.SW, layout.Start
+		case ToastBottomCenter:
+			direction, alignment = layout.S, layout.Middle
+		case ToastTopRight:
+			direction = layout.NE
+		case ToastTopLeft:
+			direction, alignment = layout.NW, layout.Start
+		case ToastTopCenter:
+			direction, alignment = layout.N, layout.Middle
+		}
+
+		// Newest toasts sit closest to the window edge
+		children := make([]layout.FlexChild, 0, 2*len(toasts))
+		for i := range toasts {
+			ts := toasts[len(toasts)-1-i]
+			if !top {
+				ts = toasts[i]
+			}
+			if len(children) > 0 {
+				children = append(children, layout.Rigid(layout.Spacer{Height: kit.Spacing.Small}.Layout))
+			}
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return kit.layoutToast(gtx, ts)
+			}))
+		}
+
+		gtx.Constraints.Min = gtx.Constraints.Max
+		return layout.UniformInset(kit.Spacing.Large).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return direction.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min = image.Point{}
+				gtx.Constraints.Max.X = min(gtx.Constraints.Max.X, gtx.Dp(toastMaxWidth))
+				return layout.Flex{Axis: layout.Vertical, Alignment: alignment}.Layout(gtx, children...)
+			})
+		})
+	}
+}
+
+func (kit *UIKit) layoutToast(gtx layout.Context, ts *toastState) layout.Dimensions {
+	var accent color.NRGBA
+	var icon string
+	switch ts.Variant {
+	case AlertSuccess:
+		accent, icon = kit.Colors.Success, IconCheckCircle
+	case AlertWarning:
+		accent, icon = kit.Colors.Warning, IconWarning
+	case AlertError:
+		accent, icon = kit.Colors.Error, IconError
+	default:
+		accent, icon = kit.Colors.Info, IconInfo
+	}
+	radius := gtx.Dp(kit.Radius.Medium)
+
+	// Record the toast so its hover area can enclose the buttons; hovering
+	// them then still counts as hovering the toast
+	macro := op.Record(gtx.Ops)
+	dims := layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			defer clip.UniformRRect(image.Rectangle{Max: size}, radius).Push(gtx.Ops).Pop()
+			paint.Fill(gtx.Ops, kit.Colors.SurfaceElevated)
+			// Variant stripe along the leading edge
+			paint.FillShape(gtx.Ops, accent, clip.Rect{Max: image.Pt(gtx.Dp(4), size.Y)}.Op())
+			kit.strokeRRect(gtx, size, kit.Radius.Medium, unit.Dp(1), kit.Colors.Border)
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top: kit.Spacing.Small, Bottom: kit.Spacing.Small,
+				Left: kit.Spacing.Medium, Right: kit.Spacing.Small,
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(kit.Icon(icon, unit.Dp(20), accent)),
+					layout.Rigid(layout.Spacer{Width: kit.Spacing.Small}.Layout),
+					layout.Flexed(1, kit.Label(ts.Message, kit.Typography.BodyMedium, kit.Colors.OnSurfaceElevated).Layout),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ts.Action == "" {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: kit.Spacing.Small}.Layout(gtx,
+							kit.Button(&ts.action, ts.Action, ButtonGhost, ButtonSmall))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						btn := kit.ButtonStyle(&ts.close, "", ButtonGhost, ButtonSmall)
+						btn.LeadingIcon = kit.Icons.Get(IconClose)
+						btn.Description = "Dismiss"
+						return btn.Layout(gtx)
+					}),
+				)
+			})
+		}),
+	)
+	call := macro.Stop()
+
+	kit.PaintShadow(gtx, dims.Size, kit.Radius.Medium, Elevation3)
+	defer clip.UniformRRect(image.Rectangle{Max: dims.Size}, radius).Push(gtx.Ops).Pop()
+	ts.hover.Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	return dims
+}
@@ -0,0 +1,510 @@
+package uikit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gioui.org/font"
+	"gioui.org/unit"
+)
+
+// Design token files
+//
+// A token file is a JSON object with optional "base", "colors", "spacing",
+// "typography", "radius", "shadows" and "motion" sections. Keys are the
+// lowerCamel names of the matching Go fields:
+//
+//	{
+//	  "base": "dark",
+//	  "colors": {"primary500": "#64748B", "focus": "#3894F660"},
+//	  "spacing": {"medium": "16dp"},
+//	  "typography": {"titleMedium": {"size": "16sp", "lineHeight": "24sp", "weight": 500}},
+//	  "radius": {"medium": "8dp"},
+//	  "shadows": {"small": "2dp"},
+//	  "motion": {"fast": "100ms", "reduced": false}
+//	}
+//
+// Anything left out falls back to the defaults of the chosen base palette.
+// Colors that differ from the base palette stay in place when SetTheme later
+// switches palettes.
+
+// TokenOptions controls how token files are validated
+type TokenOptions struct {
+	// Strict reports tokens missing from the file instead of silently
+	// falling back to the defaults
+	Strict bool
+}
+
+// TokenError describes one problem found in a token file
+type TokenError struct {
+	Path string // Dotted key path, e.g. "colors.primary500"
+	Msg  string
+}
+
+func (e *TokenError) Error() string {
+	return e.Path + ": " + e.Msg
+}
+
+// TokenErrors collects every problem found while loading a token file
+type TokenErrors []*TokenError
+
+func (errs TokenErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return "invalid design tokens:\n\t" + strings.Join(msgs, "\n\t")
+}
+
+// LoadTokens builds a UI kit from a JSON token file
+func LoadTokens(r io.Reader, opts TokenOptions) (*UIKit, error) {
+	var sections map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&sections); err != nil {
+		return nil, fmt.Errorf("invalid design tokens: %w", err)
+	}
+
+	d := tokenDecoder{strict: opts.Strict}
+	kit := NewUIKit()
+
+	if raw, ok := sections["base"]; ok {
+		var base string
+		switch err := json.Unmarshal(raw, &base); {
+		case err != nil:
+			d.fail("base", "must be a string")
+		case base == "light":
+		case base == "dark":
+			kit.mode = ThemeDark
+			kit.Colors = NewDarkColorPalette()
+		default:
+			d.fail("base", fmt.Sprintf("unknown base %q, want \"light\" or \"dark\"", base))
+		}
+	}
+
+	// Only colors that differ from the base palette are overrides; files
+	// written by WriteTokens hold the base palette's colors too
+	kit.colorTokens = make(map[string]color.NRGBA)
+	d.section(sections, "colors", &kit.Colors, func(path string, raw json.RawMessage, field reflect.Value) {
+		n, base := len(d.errs), field.Interface()
+		d.color(path, raw, field)
+		if len(d.errs) == n && field.Interface() != base {
+			kit.colorTokens[strings.TrimPrefix(path, "colors.")] = field.Interface().(color.NRGBA)
+		}
+	})
+	d.section(sections, "spacing", &kit.Spacing, d.dp)
+	d.section(sections, "typography", &kit.Typography, d.typographyStyle)
+	d.section(sections, "radius", &kit.Radius, d.dp)
+	d.section(sections, "shadows", &kit.Shadows, d.dp)
+	d.section(sections, "motion", &kit.Motion, d.motion)
+
+	for _, name := range sortedKeys(sections) {
+		switch name {
+		case "base", "colors", "spacing", "typography", "radius", "shadows", "motion":
+		default:
+			d.fail(name, "unknown section")
+		}
+	}
+
+	if len(d.errs) > 0 {
+		return nil, d.errs
+	}
+	kit.syncTheme()
+	return kit, nil
+}
+
+// applyColorTokens layers the colors set by a token file over kit.Colors, so
+// they survive switching between the light and dark palettes
+func (kit *UIKit) applyColorTokens() {
+	st := reflect.ValueOf(&kit.Colors).Elem()
+	for i := 0; i < st.NumField(); i++ {
+		if c, ok := kit.colorTokens[tokenKey(st.Type().Field(i).Name)]; ok {
+			st.Field(i).Set(reflect.ValueOf(c))
+		}
+	}
+}
+
+// LoadTokensFile builds a UI kit from the JSON token file at path
+func LoadTokensFile(path string, opts TokenOptions) (*UIKit, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadTokens(f, opts)
+}
+
+// WriteTokens serializes the kit's current tokens in the format read by LoadTokens
+func (kit *UIKit) WriteTokens(w io.Writer) error {
+	base := "light"
+	if kit.IsDark() {
+		base = "dark"
+	}
+	doc := tokenObject{
+		{"base", base},
+		{"colors", encodeSection(kit.Colors, func(v reflect.Value) any {
+			return formatHexColor(v.Interface().(color.NRGBA))
+		})},
+		{"spacing", encodeSection(kit.Spacing, encodeDp)},
+		{"typography", encodeSection(kit.Typography, func(v reflect.Value) any {
+			style := v.Interface().(TypographyStyle)
+			obj := tokenObject{
+				{"size", fmt.Sprintf("%gsp", style.Size)},
+				{"lineHeight", fmt.Sprintf("%gsp", style.LineHeight)},
+				{"weight", int(style.Weight) + 400},
+			}
+			if style.Style == font.Italic {
+				obj = append(obj, tokenEntry{"style", "italic"})
+			}
+			if style.Typeface != "" {
+				obj = append(obj, tokenEntry{"typeface", string(style.Typeface)})
+			}
+			return obj
+		})},
+		{"radius", encodeSection(kit.Radius, encodeDp)},
+		{"shadows", encodeSection(kit.Shadows, encodeDp)},
+		{"motion", encodeSection(kit.Motion, func(v reflect.Value) any {
+			if d, ok := v.Interface().(time.Duration); ok {
+				return d.String()
+			}
+			return v.Interface()
+		})},
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// SaveTokensFile writes the kit's current tokens to path
+func (kit *UIKit) SaveTokensFile(path string) error {
+	var buf bytes.Buffer
+	if err := kit.WriteTokens(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+type tokenDecoder struct {
+	strict bool
+	errs   TokenErrors
+}
+
+func (d *tokenDecoder) fail(path, msg string) {
+	d.errs = append(d.errs, &TokenError{Path: path, Msg: msg})
+}
+
+// section decodes one token section into the struct pointed to by dst,
+// matching keys to fields by tokenKey and decoding each value with decode.
+func (d *tokenDecoder) section(sections map[string]json.RawMessage, name string, dst any, decode func(path string, raw json.RawMessage, field reflect.Value)) {
+	st := reflect.ValueOf(dst).Elem()
+	raw, ok := sections[name]
+	if !ok {
+		if d.strict {
+			d.fail(name, "missing section")
+		}
+		return
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		d.fail(name, "must be an object")
+		return
+	}
+
+	fields := make(map[string]int, st.NumField())
+	for i := 0; i < st.NumField(); i++ {
+		fields[tokenKey(st.Type().Field(i).Name)] = i
+	}
+
+	for _, key := range sortedKeys(values) {
+		i, ok := fields[key]
+		if !ok {
+			d.fail(name+"."+key, "unknown token"+suggestKey(key, fields))
+			continue
+		}
+		decode(name+"."+key, values[key], st.Field(i))
+	}
+
+	if d.strict {
+		for i := 0; i < st.NumField(); i++ {
+			key := tokenKey(st.Type().Field(i).Name)
+			if _, ok := values[key]; !ok {
+				d.fail(name+"."+key, "missing token")
+			}
+		}
+	}
+}
+
+func (d *tokenDecoder) color(path string, raw json.RawMessage, field reflect.Value) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		d.fail(path, "must be a hex color string")
+		return
+	}
+	c, err := parseHexColor(s)
+	if err != nil {
+		d.fail(path, err.Error())
+		return
+	}
+	field.Set(reflect.ValueOf(c))
+}
+
+func (d *tokenDecoder) dp(path string, raw json.RawMessage, field reflect.Value) {
+	v, ok := d.dimension(path, raw, "dp")
+	if ok {
+		field.Set(reflect.ValueOf(unit.Dp(v)))
+	}
+}
+
+// motion decodes durations such as "150ms" and the reduced flag
+func (d *tokenDecoder) motion(path string, raw json.RawMessage, field reflect.Value) {
+	if field.Kind() == reflect.Bool {
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			d.fail(path, "must be true or false")
+			return
+		}
+		field.SetBool(b)
+		return
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		d.fail(path, `must be a duration string such as "150ms"`)
+		return
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		d.fail(path, fmt.Sprintf("invalid duration %q", s))
+		return
+	}
+	if v < 0 {
+		d.fail(path, "must not be negative")
+		return
+	}
+	field.Set(reflect.ValueOf(v))
+}
+
+func (d *tokenDecoder) typographyStyle(path string, raw json.RawMessage, field reflect.Value) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		d.fail(path, "must be an object")
+		return
+	}
+
+	style := field.Interface().(TypographyStyle)
+	for _, key := range sortedKeys(values) {
+		raw := values[key]
+		switch key {
+		case "size":
+			if v, ok := d.dimension(path+".size", raw, "sp"); ok {
+				style.Size = unit.Sp(v)
+			}
+		case "lineHeight":
+			if v, ok := d.dimension(path+".lineHeight", raw, "sp"); ok {
+				style.LineHeight = unit.Sp(v)
+			}
+		case "weight":
+			if w, ok := d.weight(path+".weight", raw); ok {
+				style.Weight = w
+			}
+		case "style":
+			var v string
+			switch err := json.Unmarshal(raw, &v); {
+			case err != nil:
+				d.fail(path+".style", "must be a string")
+			case v == "normal":
+				style.Style = font.Regular
+			case v == "italic":
+				style.Style = font.Italic
+			default:
+				d.fail(path+".style", `must be "normal" or "italic"`)
+			}
+		case "typeface":
+			if err := json.Unmarshal(raw, &style.Typeface); err != nil {
+				d.fail(path+".typeface", "must be a string")
+			}
+		default:
+			d.fail(path+"."+key, `unknown property, want "size", "lineHeight", "weight", "style" or "typeface"`)
+		}
+	}
+	if d.strict {
+		for _, key := range []string{"size", "lineHeight", "weight"} {
+			if _, ok := values[key]; !ok {
+				d.fail(path+"."+key, "missing token")
+			}
+		}
+	}
+	field.Set(reflect.ValueOf(style))
+}
+
+// dimension accepts either a bare number or a string with the given unit suffix
+func (d *tokenDecoder) dimension(path string, raw json.RawMessage, suffix string) (float32, bool) {
+	var num float64
+	if err := json.Unmarshal(raw, &num); err == nil {
+		return d.nonNegative(path, num)
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		d.fail(path, fmt.Sprintf("must be a number or a %q string", "12"+suffix))
+		return 0, false
+	}
+	num, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), suffix), 64)
+	if err != nil {
+		d.fail(path, fmt.Sprintf("invalid %s value %q", suffix, s))
+		return 0, false
+	}
+	return d.nonNegative(path, num)
+}
+
+func (d *tokenDecoder) nonNegative(path string, v float64) (float32, bool) {
+	if v < 0 {
+		d.fail(path, "must not be negative")
+		return 0, false
+	}
+	return float32(v), true
+}
+
+var weightNames = map[string]int{
+	"thin": 100, "extralight": 200, "light": 300, "normal": 400, "regular": 400,
+	"medium": 500, "semibold": 600, "bold": 700, "extrabold": 800, "black": 900,
+}
+
+// weight accepts CSS numeric weights (as number or string) and their common
+// names. Gio weights are offsets from normal, so CSS 500 becomes font.Medium.
+func (d *tokenDecoder) weight(path string, raw json.RawMessage) (font.Weight, bool) {
+	var w int
+	if err := json.Unmarshal(raw, &w); err != nil {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			d.fail(path, "must be a number or a weight name")
+			return 0, false
+		}
+		if n, ok := weightNames[strings.ToLower(s)]; ok {
+			w = n
+		} else if w, err = strconv.Atoi(s); err != nil {
+			d.fail(path, fmt.Sprintf("unknown font weight %q", s))
+			return 0, false
+		}
+	}
+	if w < 100 || w > 900 || w%100 != 0 {
+		d.fail(path, fmt.Sprintf("font weight %d must be a multiple of 100 between 100 and 900", w))
+		return 0, false
+	}
+	return font.Weight(w - 400), true
+}
+
+// parseHexColor parses #RGB, #RRGGBB and #RRGGBBAA colors
+func parseHexColor(s string) (color.NRGBA, error) {
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok {
+		return color.NRGBA{}, fmt.Errorf("color %q must start with '#'", s)
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "FF"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q, want #RGB, #RRGGBB or #RRGGBBAA", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func formatHexColor(c color.NRGBA) string {
+	if c.A == 0xFF {
+		return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+}
+
+// tokenKey converts a Go field name to its token key: Primary500 becomes
+// primary500, XXLarge becomes xxLarge and XL becomes xl.
+func tokenKey(name string) string {
+	r := []rune(name)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// suggestKey hints at a known key that differs from key only in case
+func suggestKey(key string, fields map[string]int) string {
+	for known := range fields {
+		if strings.EqualFold(known, key) {
+			return fmt.Sprintf(" (did you mean %q?)", known)
+		}
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func encodeDp(v reflect.Value) any {
+	return fmt.Sprintf("%gdp", v.Interface().(unit.Dp))
+}
+
+func encodeSection(section any, encode func(reflect.Value) any) tokenObject {
+	st := reflect.ValueOf(section)
+	obj := make(tokenObject, st.NumField())
+	for i := range obj {
+		obj[i] = tokenEntry{tokenKey(st.Type().Field(i).Name), encode(st.Field(i))}
+	}
+	return obj
+}
+
+// tokenObject is a JSON object that keeps its keys in declaration order
+type tokenObject []tokenEntry
+
+type tokenEntry struct {
+	key   string
+	value any
+}
+
+func (o tokenObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(e.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,141 @@
+package uikit
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTokens = `{
+  "base": "dark",
+  "colors": {"primary500": "#64748B", "focus": "#3894F660"},
+  "spacing": {"medium": "18dp"},
+  "typography": {"titleMedium": {"size": "17sp", "lineHeight": "25sp", "weight": "semibold", "style": "italic", "typeface": "Inter"}},
+  "radius": {"medium": 6},
+  "shadows": {"small": "3dp"},
+  "motion": {"fast": "80ms", "reduced": true}
+}`
+
+// tokenErrorPaths returns the paths of the token errors in err
+func tokenErrorPaths(t *testing.T, err error) []string {
+	t.Helper()
+	var errs TokenErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error = %v; want TokenErrors", err)
+	}
+	paths := make([]string, len(errs))
+	for i, e := range errs {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+func TestTokensRoundTrip(t *testing.T) {
+	kit, err := LoadTokens(strings.NewReader(testTokens), TokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := kit.WriteTokens(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// A written file holds every token, so it passes a strict load
+	again, err := LoadTokens(bytes.NewReader(buf.Bytes()), TokenOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.IsDark() {
+		t.Error("reloaded kit is not dark")
+	}
+	for _, section := range []struct {
+		name      string
+		got, want any
+	}{
+		{"colors", again.Colors, kit.Colors},
+		{"spacing", again.Spacing, kit.Spacing},
+		{"typography", again.Typography, kit.Typography},
+		{"radius", again.Radius, kit.Radius},
+		{"shadows", again.Shadows, kit.Shadows},
+		{"motion", again.Motion, kit.Motion},
+	} {
+		if !reflect.DeepEqual(section.got, section.want) {
+			t.Errorf("%s changed in the round trip:\n got %+v\nwant %+v", section.name, section.got, section.want)
+		}
+	}
+}
+
+func TestTokenColorsSurviveSetTheme(t *testing.T) {
+	kit, err := LoadTokens(strings.NewReader(testTokens), TokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kit.SetTheme(ThemeLight)
+	want := color.NRGBA{R: 0x64, G: 0x74, B: 0x8B, A: 0xFF}
+	if kit.Colors.Primary500 != want {
+		t.Errorf("Primary500 = %v after SetTheme; want %v", kit.Colors.Primary500, want)
+	}
+	if kit.Colors.Background != NewColorPalette().Background {
+		t.Errorf("Background = %v; want the light palette's", kit.Colors.Background)
+	}
+}
+
+func TestSavedTokensSwitchTheme(t *testing.T) {
+	saved := func(kit *UIKit) *UIKit {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := kit.WriteTokens(&buf); err != nil {
+			t.Fatal(err)
+		}
+		again, err := LoadTokens(&buf, TokenOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return again
+	}
+
+	kit := saved(NewUIKit())
+	kit.SetTheme(ThemeDark)
+	if kit.Colors != NewDarkColorPalette() {
+		t.Error("a saved light kit does not switch to the dark palette")
+	}
+
+	loaded, err := LoadTokens(strings.NewReader(testTokens), TokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kit = saved(loaded)
+	kit.SetTheme(ThemeLight)
+	want := NewColorPalette()
+	want.Primary500 = color.NRGBA{R: 0x64, G: 0x74, B: 0x8B, A: 0xFF}
+	want.Focus = color.NRGBA{R: 0x38, G: 0x94, B: 0xF6, A: 0x60}
+	if kit.Colors != want {
+		t.Errorf("saved dark kit switched to light has colors\n%+v\nwant the light palette with the file's overrides\n%+v", kit.Colors, want)
+	}
+}
+
+func TestTokenErrors(t *testing.T) {
+	tests := []struct {
+		tokens string
+		paths  []string
+	}{
+		{`{"colors": {"primary501": "#FFF"}}`, []string{"colors.primary501"}},
+		{`{"typography": {"bodyLarge": {"colour": "#FFF"}}}`, []string{"typography.bodyLarge.colour"}},
+		{`{"palette": {}}`, []string{"palette"}},
+		{`{"colors": {"primary500": "blue"}}`, []string{"colors.primary500"}},
+		{`{"colors": {"primary500": "#12345"}}`, []string{"colors.primary500"}},
+		{`{"spacing": {"medium": "12px"}}`, []string{"spacing.medium"}},
+		{`{"typography": {"bodyLarge": {"size": "-2sp"}}}`, []string{"typography.bodyLarge.size"}},
+		{`{"typography": {"bodyLarge": {"style": 3}}}`, []string{"typography.bodyLarge.style"}},
+		{`{"base": 1}`, []string{"base"}},
+		{`{"base": "sepia", "radius": {"small": true}}`, []string{"base", "radius.small"}},
+	}
+	for _, tt := range tests {
+		_, err := LoadTokens(strings.NewReader(tt.tokens), TokenOptions{})
+		if paths := tokenErrorPaths(t, err); !reflect.DeepEqual(paths, tt.paths) {
+			t.Errorf("LoadTokens(%s) failed at %q; want %q", tt.tokens, paths, tt.paths)
+		}
+	}
+}
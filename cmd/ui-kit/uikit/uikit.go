@@ -3,12 +3,15 @@ package uikit
 import (
 	"image"
 	"image/color"
+	"os"
+	"strings"
+	"time"
 
+	"gioui.org/font"
 	"gioui.org/layout"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
 	"gioui.org/unit"
-	"gioui.org/widget"
 	"gioui.org/widget/material"
 )
 
@@ -87,6 +90,12 @@ type ColorPalette struct {
 	Overlay color.NRGBA
 	Focus   color.NRGBA
 
+	// Text on the *Light semantic containers (badges, alerts)
+	OnSuccessLight color.NRGBA
+	OnWarningLight color.NRGBA
+	OnErrorLight   color.NRGBA
+	OnInfoLight    color.NRGBA
+
 	OnBackground      color.NRGBA
 	OnSurface         color.NRGBA
 	OnPrimary         color.NRGBA
@@ -128,13 +137,13 @@ func NewColorPalette() ColorPalette {
 		Gray900: color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF},
 
 		// Semantic Colors
-		Success:      color.NRGBA{R: 0x10, G: 0xB9, B: 0x81, A: 0xFF},
+		Success:      color.NRGBA{R: 0x04, G: 0x78, B: 0x57, A: 0xFF},
 		SuccessLight: color.NRGBA{R: 0xD1, G: 0xFA, B: 0xE5, A: 0xFF},
-		Warning:      color.NRGBA{R: 0xF5, G: 0x9E, B: 0x0B, A: 0xFF},
+		Warning:      color.NRGBA{R: 0xB4, G: 0x53, B: 0x09, A: 0xFF},
 		WarningLight: color.NRGBA{R: 0xFE, G: 0xF3, B: 0xC7, A: 0xFF},
-		Error:        color.NRGBA{R: 0xEF, G: 0x44, B: 0x44, A: 0xFF},
+		Error:        color.NRGBA{R: 0xDC, G: 0x26, B: 0x26, A: 0xFF},
 		ErrorLight:   color.NRGBA{R: 0xFE, G: 0xE2, B: 0xE2, A: 0xFF},
-		Info:         color.NRGBA{R: 0x38, G: 0x94, B: 0xF6, A: 0xFF},
+		Info:         color.NRGBA{R: 0x25, G: 0x63, B: 0xEB, A: 0xFF},
 		InfoLight:    color.NRGBA{R: 0xDB, G: 0xEA, B: 0xFE, A: 0xFF},
 
 		// Surface Colors
@@ -159,14 +168,20 @@ func NewColorPalette() ColorPalette {
 		Overlay: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x40},
 		Focus:   color.NRGBA{R: 0x38, G: 0x94, B: 0xF6, A: 0x60},
 
-		// On Colors - Fixed for proper contrast
+		// Text on semantic containers
+		OnSuccessLight: color.NRGBA{R: 0x06, G: 0x5F, B: 0x46, A: 0xFF},
+		OnWarningLight: color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF},
+		OnErrorLight:   color.NRGBA{R: 0x99, G: 0x1B, B: 0x1B, A: 0xFF},
+		OnInfoLight:    color.NRGBA{R: 0x1E, G: 0x40, B: 0xAF, A: 0xFF},
+
+		// On Colors - checked by the contrast package tests
 		OnBackground:      color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on light background
 		OnSurface:         color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on white surface
 		OnPrimary:         color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, // White text on primary color
 		OnSecondary:       color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on light secondary
 		OnError:           color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, // White text on error color
 		OnSuccess:         color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, // White text on success color
-		OnWarning:         color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on warning color (better contrast)
+		OnWarning:         color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, // White text on warning color
 		OnInfo:            color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, // White text on info color
 		OnSurfaceElevated: color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on elevated surface
 		OnSurfaceDisabled: color.NRGBA{R: 0x9C, G: 0xA3, B: 0xAF, A: 0xFF}, // Muted text on disabled surface
@@ -174,6 +189,89 @@ func NewColorPalette() ColorPalette {
 	}
 }
 
+// NewDarkColorPalette creates the dark counterpart of NewColorPalette.
+// The primary and gray scales are inverted so that components addressing
+// them by step (hover shades, tracks, secondary fills) keep their relative
+// contrast against the dark surfaces.
+func NewDarkColorPalette() ColorPalette {
+	return ColorPalette{
+		// Primary Blue-Gray Scale (inverted)
+		Primary50:  color.NRGBA{R: 0x0F, G: 0x17, B: 0x2A, A: 0xFF},
+		Primary100: color.NRGBA{R: 0x1E, G: 0x29, B: 0x3B, A: 0xFF},
+		Primary200: color.NRGBA{R: 0x33, G: 0x41, B: 0x55, A: 0xFF},
+		Primary300: color.NRGBA{R: 0x47, G: 0x55, B: 0x69, A: 0xFF},
+		Primary400: color.NRGBA{R: 0x64, G: 0x74, B: 0x8B, A: 0xFF},
+		Primary500: color.NRGBA{R: 0x94, G: 0xA3, B: 0xB8, A: 0xFF}, // Main primary
+		Primary600: color.NRGBA{R: 0xCB, G: 0xD5, B: 0xE1, A: 0xFF},
+		Primary700: color.NRGBA{R: 0xE2, G: 0xE8, B: 0xF0, A: 0xFF},
+		Primary800: color.NRGBA{R: 0xF1, G: 0xF5, B: 0xF9, A: 0xFF},
+		Primary900: color.NRGBA{R: 0xF8, G: 0xFA, B: 0xFC, A: 0xFF},
+
+		// Neutral Gray Scale (inverted)
+		Gray50:  color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF},
+		Gray100: color.NRGBA{R: 0x1F, G: 0x29, B: 0x37, A: 0xFF},
+		Gray200: color.NRGBA{R: 0x37, G: 0x41, B: 0x51, A: 0xFF},
+		Gray300: color.NRGBA{R: 0x4B, G: 0x55, B: 0x63, A: 0xFF},
+		Gray400: color.NRGBA{R: 0x6B, G: 0x72, B: 0x80, A: 0xFF},
+		Gray500: color.NRGBA{R: 0x9C, G: 0xA3, B: 0xAF, A: 0xFF},
+		Gray600: color.NRGBA{R: 0xD1, G: 0xD5, B: 0xDB, A: 0xFF},
+		Gray700: color.NRGBA{R: 0xE5, G: 0xE7, B: 0xEB, A: 0xFF},
+		Gray800: color.NRGBA{R: 0xF3, G: 0xF4, B: 0xF6, A: 0xFF},
+		Gray900: color.NRGBA{R: 0xF9, G: 0xFA, B: 0xFB, A: 0xFF},
+
+		// Semantic Colors - lighter tones, dark containers
+		Success:      color.NRGBA{R: 0x34, G: 0xD3, B: 0x99, A: 0xFF},
+		SuccessLight: color.NRGBA{R: 0x06, G: 0x4E, B: 0x3B, A: 0xFF},
+		Warning:      color.NRGBA{R: 0xFB, G: 0xBF, B: 0x24, A: 0xFF},
+		WarningLight: color.NRGBA{R: 0x78, G: 0x35, B: 0x0F, A: 0xFF},
+		Error:        color.NRGBA{R: 0xF8, G: 0x71, B: 0x71, A: 0xFF},
+		ErrorLight:   color.NRGBA{R: 0x7F, G: 0x1D, B: 0x1D, A: 0xFF},
+		Info:         color.NRGBA{R: 0x60, G: 0xA5, B: 0xFA, A: 0xFF},
+		InfoLight:    color.NRGBA{R: 0x1E, G: 0x3A, B: 0x8A, A: 0xFF},
+
+		// Surface Colors
+		White:           color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+		Background:      color.NRGBA{R: 0x0F, G: 0x17, B: 0x2A, A: 0xFF},
+		Surface:         color.NRGBA{R: 0x1E, G: 0x29, B: 0x3B, A: 0xFF},
+		SurfaceElevated: color.NRGBA{R: 0x27, G: 0x34, B: 0x49, A: 0xFF},
+
+		// Text Colors
+		TextPrimary:   color.NRGBA{R: 0xF9, G: 0xFA, B: 0xFB, A: 0xFF},
+		TextSecondary: color.NRGBA{R: 0x9C, G: 0xA3, B: 0xAF, A: 0xFF},
+		TextDisabled:  color.NRGBA{R: 0x6B, G: 0x72, B: 0x80, A: 0xFF},
+		TextInverse:   color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF},
+
+		// Border Colors
+		Border:      color.NRGBA{R: 0x37, G: 0x41, B: 0x51, A: 0xFF},
+		BorderLight: color.NRGBA{R: 0x2A, G: 0x35, B: 0x47, A: 0xFF},
+		BorderHover: color.NRGBA{R: 0x4B, G: 0x55, B: 0x63, A: 0xFF},
+
+		// Special Colors
+		Shadow:  color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x40},
+		Overlay: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x99},
+		Focus:   color.NRGBA{R: 0x60, G: 0xA5, B: 0xFA, A: 0x80},
+
+		// Text on semantic containers
+		OnSuccessLight: color.NRGBA{R: 0xD1, G: 0xFA, B: 0xE5, A: 0xFF},
+		OnWarningLight: color.NRGBA{R: 0xFE, G: 0xF3, B: 0xC7, A: 0xFF},
+		OnErrorLight:   color.NRGBA{R: 0xFE, G: 0xE2, B: 0xE2, A: 0xFF},
+		OnInfoLight:    color.NRGBA{R: 0xDB, G: 0xEA, B: 0xFE, A: 0xFF},
+
+		// On Colors
+		OnBackground:      color.NRGBA{R: 0xF9, G: 0xFA, B: 0xFB, A: 0xFF}, // Light text on dark background
+		OnSurface:         color.NRGBA{R: 0xF9, G: 0xFA, B: 0xFB, A: 0xFF}, // Light text on dark surface
+		OnPrimary:         color.NRGBA{R: 0x0F, G: 0x17, B: 0x2A, A: 0xFF}, // Dark text on light primary
+		OnSecondary:       color.NRGBA{R: 0xF9, G: 0xFA, B: 0xFB, A: 0xFF}, // Light text on dark secondary
+		OnError:           color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on light error
+		OnSuccess:         color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on light success
+		OnWarning:         color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on warning color
+		OnInfo:            color.NRGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xFF}, // Dark text on light info
+		OnSurfaceElevated: color.NRGBA{R: 0xF9, G: 0xFA, B: 0xFB, A: 0xFF}, // Light text on elevated surface
+		OnSurfaceDisabled: color.NRGBA{R: 0x6B, G: 0x72, B: 0x80, A: 0xFF}, // Muted text on disabled surface
+		OnSurfaceVariant:  color.NRGBA{R: 0xCB, G: 0xD5, B: 0xE1, A: 0xFF}, // Medium contrast text on variant surface
+	}
+}
+
 // Spacing system based on 4px base unit
 type Spacing struct {
 	None     unit.Dp // 0px
@@ -199,6 +297,65 @@ func NewSpacing() Spacing {
 	}
 }
 
+// Corner radius scale, defaulting to the Radius* constants
+type Radius struct {
+	Small  unit.Dp
+	Medium unit.Dp
+	Large  unit.Dp
+	XL     unit.Dp
+}
+
+func NewRadius() Radius {
+	return Radius{
+		Small:  RadiusSmall,
+		Medium: RadiusMedium,
+		Large:  RadiusLarge,
+		XL:     RadiusXL,
+	}
+}
+
+// Shadow blur scale, defaulting to the Shadow* constants. Elevations 1 to 3
+// blur by Small, Medium and Large; 4 and 5 by 1.5 and 2 times Large.
+type Shadows struct {
+	Small  unit.Dp
+	Medium unit.Dp
+	Large  unit.Dp
+}
+
+func NewShadows() Shadows {
+	return Shadows{
+		Small:  ShadowSmall,
+		Medium: ShadowMedium,
+		Large:  ShadowLarge,
+	}
+}
+
+// Motion durations for transitions, and the reduced-motion preference
+type Motion struct {
+	Fast   time.Duration // 100ms: hover and press feedback
+	Medium time.Duration // 200ms: elements appearing and leaving
+	Slow   time.Duration // 400ms: progress and larger movements
+	// Reduced turns transitions into instant changes, for users who are
+	// sensitive to motion. Loading indicators keep moving.
+	Reduced bool
+}
+
+func NewMotion() Motion {
+	return Motion{
+		Fast:   100 * time.Millisecond,
+		Medium: 200 * time.Millisecond,
+		Slow:   400 * time.Millisecond,
+	}
+}
+
+// Duration returns d, or zero when motion is reduced
+func (m Motion) Duration(d time.Duration) time.Duration {
+	if m.Reduced {
+		return 0
+	}
+	return d
+}
+
 // Typography system with consistent hierarchy
 type Typography struct {
 	DisplayLarge   TypographyStyle
@@ -216,40 +373,78 @@ type Typography struct {
 	LabelLarge     TypographyStyle
 	LabelMedium    TypographyStyle
 	LabelSmall     TypographyStyle
+	Mono           TypographyStyle // Code, identifiers and tabular figures
 }
 
 type TypographyStyle struct {
 	Size       unit.Sp
-	LineHeight float32
-	Weight     string
+	LineHeight unit.Sp // Baseline-to-baseline distance; zero uses the font's own
+	Weight     font.Weight
+	Style      font.Style
+	Typeface   font.Typeface // Empty uses the theme's default face
+}
+
+// Font returns the font description for the style
+func (s TypographyStyle) Font() font.Font {
+	return font.Font{Typeface: s.Typeface, Style: s.Style, Weight: s.Weight}
 }
 
 func NewTypography() Typography {
 	return Typography{
-		DisplayLarge:   TypographyStyle{Size: unit.Sp(57), LineHeight: 64, Weight: "400"},
-		DisplayMedium:  TypographyStyle{Size: unit.Sp(45), LineHeight: 52, Weight: "400"},
-		DisplaySmall:   TypographyStyle{Size: unit.Sp(36), LineHeight: 44, Weight: "400"},
-		HeadlineLarge:  TypographyStyle{Size: unit.Sp(32), LineHeight: 40, Weight: "400"},
-		HeadlineMedium: TypographyStyle{Size: unit.Sp(28), LineHeight: 36, Weight: "400"},
-		HeadlineSmall:  TypographyStyle{Size: unit.Sp(24), LineHeight: 32, Weight: "400"},
-		TitleLarge:     TypographyStyle{Size: unit.Sp(22), LineHeight: 28, Weight: "500"},
-		TitleMedium:    TypographyStyle{Size: unit.Sp(16), LineHeight: 24, Weight: "500"},
-		TitleSmall:     TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: "500"},
-		BodyLarge:      TypographyStyle{Size: unit.Sp(16), LineHeight: 24, Weight: "400"},
-		BodyMedium:     TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: "400"},
-		BodySmall:      TypographyStyle{Size: unit.Sp(12), LineHeight: 16, Weight: "400"},
-		LabelLarge:     TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: "500"},
-		LabelMedium:    TypographyStyle{Size: unit.Sp(12), LineHeight: 16, Weight: "500"},
-		LabelSmall:     TypographyStyle{Size: unit.Sp(11), LineHeight: 16, Weight: "500"},
+		DisplayLarge:   TypographyStyle{Size: unit.Sp(57), LineHeight: 64, Weight: font.Normal},
+		DisplayMedium:  TypographyStyle{Size: unit.Sp(45), LineHeight: 52, Weight: font.Normal},
+		DisplaySmall:   TypographyStyle{Size: unit.Sp(36), LineHeight: 44, Weight: font.Normal},
+		HeadlineLarge:  TypographyStyle{Size: unit.Sp(32), LineHeight: 40, Weight: font.Normal},
+		HeadlineMedium: TypographyStyle{Size: unit.Sp(28), LineHeight: 36, Weight: font.Normal},
+		HeadlineSmall:  TypographyStyle{Size: unit.Sp(24), LineHeight: 32, Weight: font.Normal},
+		TitleLarge:     TypographyStyle{Size: unit.Sp(22), LineHeight: 28, Weight: font.Medium},
+		TitleMedium:    TypographyStyle{Size: unit.Sp(16), LineHeight: 24, Weight: font.Medium},
+		TitleSmall:     TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: font.Medium},
+		BodyLarge:      TypographyStyle{Size: unit.Sp(16), LineHeight: 24, Weight: font.Normal},
+		BodyMedium:     TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: font.Normal},
+		BodySmall:      TypographyStyle{Size: unit.Sp(12), LineHeight: 16, Weight: font.Normal},
+		LabelLarge:     TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: font.Medium},
+		LabelMedium:    TypographyStyle{Size: unit.Sp(12), LineHeight: 16, Weight: font.Medium},
+		LabelSmall:     TypographyStyle{Size: unit.Sp(11), LineHeight: 16, Weight: font.Medium},
+		Mono:           TypographyStyle{Size: unit.Sp(14), LineHeight: 20, Weight: font.Normal, Typeface: "Go Mono, monospace"},
 	}
 }
 
+// ThemeMode selects which palette the kit paints with
+type ThemeMode int
+
+const (
+	ThemeLight ThemeMode = iota
+	ThemeDark
+	ThemeSystem // Follow SystemPrefersDark
+)
+
+// SystemPrefersDark reports whether the platform asks for a dark appearance.
+// Gio does not expose the OS color scheme, so the default only honours a
+// GTK_THEME ending in ":dark"; applications with a better source (a settings
+// portal, a config file) can replace it before calling SetTheme(ThemeSystem).
+var SystemPrefersDark = func() bool {
+	return strings.HasSuffix(os.Getenv("GTK_THEME"), ":dark")
+}
+
 // Main UI Kit struct
 type UIKit struct {
 	Colors     ColorPalette
 	Spacing    Spacing
 	Typography Typography
+	Radius     Radius
+	Shadows    Shadows
+	Motion     Motion
 	Theme      *material.Theme
+	Icons      *IconRegistry
+
+	mode        ThemeMode
+	seeds       *PaletteSeeds
+	colorTokens map[string]color.NRGBA // Colors from a token file, by token key
+	fonts       fontState
+	motions     map[any]*motionEntry
+	motionSweep time.Time
+	window      windowState
 }
 
 // NewUIKit creates a new UI kit instance
@@ -258,190 +453,64 @@ func NewUIKit() *UIKit {
 		Colors:     NewColorPalette(),
 		Spacing:    NewSpacing(),
 		Typography: NewTypography(),
+		Radius:     NewRadius(),
+		Shadows:    NewShadows(),
+		Motion:     NewMotion(),
 		Theme:      material.NewTheme(),
+		Icons:      NewIconRegistry(),
 	}
-
-	// Configure theme with our colors
-	kit.Theme.Palette.Bg = kit.Colors.Background
-	kit.Theme.Palette.Fg = kit.Colors.TextPrimary
-	kit.Theme.Palette.ContrastBg = kit.Colors.Primary500
-	kit.Theme.Palette.ContrastFg = kit.Colors.TextInverse
+	kit.syncTheme()
 
 	return kit
 }
 
-// Button Variants
-type ButtonVariant int
-
-const (
-	ButtonPrimary ButtonVariant = iota
-	ButtonSecondary
-	ButtonOutline
-	ButtonGhost
-	ButtonDanger
-	ButtonSuccess
-)
-
-type ButtonSize int
-
-const (
-	ButtonSmall ButtonSize = iota
-	ButtonMedium
-	ButtonLarge
-)
-
-// Button creates a styled button with consistent design
-func (kit *UIKit) Button(btn *widget.Clickable, text string, variant ButtonVariant, size ButtonSize) layout.Widget {
-	return func(gtx layout.Context) layout.Dimensions {
-		var bg, fg color.NRGBA
-		var inset unit.Dp
-		var fontSize unit.Sp
-		var borderWidth unit.Dp = 0
-		var borderColor color.NRGBA
-
-		// Size configuration
-		switch size {
-		case ButtonSmall:
-			inset = kit.Spacing.Small
-			fontSize = kit.Typography.LabelSmall.Size
-		case ButtonMedium:
-			inset = kit.Spacing.Medium
-			fontSize = kit.Typography.LabelMedium.Size
-		case ButtonLarge:
-			inset = kit.Spacing.Large
-			fontSize = kit.Typography.LabelLarge.Size
-		}
-
-		// Variant configuration
-		switch variant {
-		case ButtonPrimary:
-			bg = kit.Colors.Primary500
-			fg = kit.Colors.OnPrimary
-		case ButtonSecondary:
-			bg = kit.Colors.Gray100
-			fg = kit.Colors.OnSecondary
-		case ButtonOutline:
-			bg = color.NRGBA{A: 0}
-			fg = kit.Colors.Primary500
-			borderWidth = unit.Dp(1)
-			borderColor = kit.Colors.Primary500
-		case ButtonGhost:
-			bg = color.NRGBA{A: 0}
-			fg = kit.Colors.Primary500
-		case ButtonDanger:
-			bg = kit.Colors.Error
-			fg = kit.Colors.OnError
-		case ButtonSuccess:
-			bg = kit.Colors.Success
-			fg = kit.Colors.OnSuccess
-		}
-
-		// Handle hover state (simplified)
-		if btn.Hovered() {
-			if variant == ButtonPrimary {
-				bg = kit.Colors.Primary600
-			} else if variant == ButtonSecondary {
-				bg = kit.Colors.Gray200
-			}
-		}
-
-		return widget.Border{
-			Color:        borderColor,
-			CornerRadius: RadiusMedium,
-			Width:        borderWidth,
-		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			return material.ButtonLayoutStyle{
-				Background:   bg,
-				CornerRadius: RadiusMedium,
-				Button:       btn,
-			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return layout.UniformInset(inset).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-					label := material.Label(kit.Theme, fontSize, text)
-					label.Color = fg
-					return label.Layout(gtx)
-				})
-			})
-		})
+// SetTheme switches the kit to the light, dark or system palette. Components
+// read kit.Colors on every frame, so the change shows on the next redraw.
+func (kit *UIKit) SetTheme(mode ThemeMode) {
+	kit.mode = mode
+	dark := kit.IsDark()
+	switch {
+	case kit.seeds != nil && dark:
+		kit.SetColors(NewDarkColorPaletteFromSeeds(*kit.seeds))
+	case kit.seeds != nil:
+		kit.SetColors(NewColorPaletteFromSeeds(*kit.seeds))
+	case dark:
+		kit.SetColors(NewDarkColorPalette())
+	default:
+		kit.SetColors(NewColorPalette())
 	}
 }
 
-// Input field with consistent styling
-func (kit *UIKit) Input(editor *widget.Editor, hint string, hasError bool) layout.Widget {
-	return func(gtx layout.Context) layout.Dimensions {
-		borderColor := kit.Colors.Border
-		bg := kit.Colors.Surface
-
-		if hasError {
-			borderColor = kit.Colors.Error
-		}
-
-		if gtx.Focused(editor) {
-			borderColor = kit.Colors.Primary500
-		}
+// SetColors installs a custom palette and re-syncs the material theme.
+// Colors set by the token file the kit was loaded from still win.
+func (kit *UIKit) SetColors(colors ColorPalette) {
+	kit.Colors = colors
+	kit.applyColorTokens()
+	kit.syncTheme()
+}
 
-		return widget.Border{
-			Color:        borderColor,
-			CornerRadius: RadiusMedium,
-			Width:        unit.Dp(1),
-		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			defer clip.RRect{
-				Rect: image.Rectangle{Max: gtx.Constraints.Max},
-				NW:   int(RadiusMedium), NE: int(RadiusMedium),
-				SE: int(RadiusMedium), SW: int(RadiusMedium),
-			}.Push(gtx.Ops).Pop()
-
-			paint.Fill(gtx.Ops, bg)
-
-			return layout.UniformInset(kit.Spacing.Medium).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				ed := material.Editor(kit.Theme, editor, hint)
-				ed.Color = kit.Colors.OnSurface
-				ed.HintColor = kit.Colors.TextSecondary
-				return ed.Layout(gtx)
-			})
-		})
-	}
+// Mode returns the theme mode last passed to SetTheme
+func (kit *UIKit) Mode() ThemeMode {
+	return kit.mode
 }
 
-// Card component with shadow and consistent styling
-func (kit *UIKit) Card(gtx layout.Context, content layout.Widget) layout.Dimensions {
-	// Draw shadow
-	shadowRect := image.Rectangle{
-		Max: image.Point{
-			X: gtx.Constraints.Max.X,
-			Y: gtx.Constraints.Max.Y + int(ShadowSmall),
-		},
+// IsDark reports whether the current mode resolves to the dark palette
+func (kit *UIKit) IsDark() bool {
+	switch kit.mode {
+	case ThemeDark:
+		return true
+	case ThemeSystem:
+		return SystemPrefersDark()
 	}
+	return false
+}
 
-	return layout.Stack{}.Layout(gtx,
-		// Shadow layer
-		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
-			defer clip.RRect{
-				Rect: shadowRect,
-				NW:   int(RadiusLarge), NE: int(RadiusLarge),
-				SE: int(RadiusLarge), SW: int(RadiusLarge),
-			}.Push(gtx.Ops).Pop()
-
-			paint.Fill(gtx.Ops, kit.Colors.Shadow)
-			return layout.Dimensions{Size: shadowRect.Max}
-		}),
-		// Main card
-		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
-			return widget.Border{
-				Color:        kit.Colors.BorderLight,
-				CornerRadius: RadiusLarge,
-				Width:        unit.Dp(1),
-			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				defer clip.RRect{
-					Rect: image.Rectangle{Max: gtx.Constraints.Max},
-					NW:   int(RadiusLarge), NE: int(RadiusLarge),
-					SE: int(RadiusLarge), SW: int(RadiusLarge),
-				}.Push(gtx.Ops).Pop()
-
-				paint.Fill(gtx.Ops, kit.Colors.Surface)
-				return layout.UniformInset(kit.Spacing.Large).Layout(gtx, content)
-			})
-		}),
-	)
+// syncTheme mirrors kit.Colors into the material theme used by labels and editors
+func (kit *UIKit) syncTheme() {
+	kit.Theme.Palette.Bg = kit.Colors.Background
+	kit.Theme.Palette.Fg = kit.Colors.TextPrimary
+	kit.Theme.Palette.ContrastBg = kit.Colors.Primary500
+	kit.Theme.Palette.ContrastFg = kit.Colors.TextInverse
 }
 
 // Badge component for status indicators
@@ -455,50 +524,73 @@ const (
 	BadgeInfo
 )
 
-func (kit *UIKit) Badge(text string, variant BadgeVariant) layout.Widget {
-	return func(gtx layout.Context) layout.Dimensions {
-		var bg, fg color.NRGBA
-
-		switch variant {
-		case BadgeDefault:
-			bg = kit.Colors.Gray200
-			fg = kit.Colors.TextPrimary
-		case BadgeSuccess:
-			bg = kit.Colors.SuccessLight
-			fg = kit.Colors.Success
-		case BadgeWarning:
-			bg = kit.Colors.WarningLight
-			fg = kit.Colors.OnWarning
-		case BadgeError:
-			bg = kit.Colors.ErrorLight
-			fg = kit.Colors.Error
-		case BadgeInfo:
-			bg = kit.Colors.InfoLight
-			fg = kit.Colors.Info
-		}
+// BadgeStyle describes a badge, a small pill with a label and an optional
+// icon before it
+type BadgeStyle struct {
+	Text    string
+	Variant BadgeVariant
+	// Icon is drawn before the text in the badge's text color
+	Icon *Icon
 
-		return widget.Border{
-			Color:        bg,
-			CornerRadius: unit.Dp(12),
-			Width:        unit.Dp(0),
-		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			defer clip.RRect{
-				Rect: image.Rectangle{Max: gtx.Constraints.Max},
-				NW:   12, NE: 12, SE: 12, SW: 12,
-			}.Push(gtx.Ops).Pop()
+	kit *UIKit
+}
+
+// Badge creates a badge with a label
+func (kit *UIKit) Badge(text string, variant BadgeVariant) layout.Widget {
+	return kit.BadgeStyle(text, variant).Layout
+}
 
-			paint.Fill(gtx.Ops, bg)
+// BadgeStyle returns a badge that can be further configured before layout
+func (kit *UIKit) BadgeStyle(text string, variant BadgeVariant) BadgeStyle {
+	return BadgeStyle{Text: text, Variant: variant, kit: kit}
+}
 
+// Layout draws the badge
+func (b BadgeStyle) Layout(gtx layout.Context) layout.Dimensions {
+	kit := b.kit
+	bg, fg := b.colors()
+	typo := kit.Typography.LabelSmall
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Constraints.Min
+			paint.FillShape(gtx.Ops, bg, clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(12)).Op(gtx.Ops))
+			return layout.Dimensions{Size: size}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
 			return layout.Inset{
 				Top: kit.Spacing.Tiny, Bottom: kit.Spacing.Tiny,
 				Left: kit.Spacing.Small, Right: kit.Spacing.Small,
 			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				label := material.Label(kit.Theme, kit.Typography.LabelSmall.Size, text)
-				label.Color = fg
-				return label.Layout(gtx)
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if b.Icon == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: kit.Spacing.Tiny}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return layoutIcon(gtx, b.Icon, gtx.Sp(max(typo.LineHeight, typo.Size)), fg)
+						})
+					}),
+					layout.Rigid(kit.Label(b.Text, typo, fg).Layout),
+				)
 			})
-		})
+		}),
+	)
+}
+
+// colors returns the background and text colors of the badge's variant
+func (b BadgeStyle) colors() (bg, fg color.NRGBA) {
+	c := b.kit.Colors
+	switch b.Variant {
+	case BadgeSuccess:
+		return c.SuccessLight, c.OnSuccessLight
+	case BadgeWarning:
+		return c.WarningLight, c.OnWarningLight
+	case BadgeError:
+		return c.ErrorLight, c.OnErrorLight
+	case BadgeInfo:
+		return c.InfoLight, c.OnInfoLight
 	}
+	return c.Gray200, c.TextPrimary
 }
 
 // Divider component
@@ -513,138 +605,6 @@ func (kit *UIKit) Divider() layout.Widget {
 	}
 }
 
-// Alert component for notifications
-type AlertVariant int
-
-const (
-	AlertInfo AlertVariant = iota
-	AlertSuccess
-	AlertWarning
-	AlertError
-)
-
-func (kit *UIKit) Alert(title, message string, variant AlertVariant) layout.Widget {
-	return func(gtx layout.Context) layout.Dimensions {
-		var bg, borderColor, iconColor color.NRGBA
-		var icon string
-
-		switch variant {
-		case AlertInfo:
-			bg = kit.Colors.InfoLight
-			borderColor = kit.Colors.Info
-			iconColor = kit.Colors.Info
-			icon = "ℹ"
-		case AlertSuccess:
-			bg = kit.Colors.SuccessLight
-			borderColor = kit.Colors.Success
-			iconColor = kit.Colors.Success
-			icon = "✓"
-		case AlertWarning:
-			bg = kit.Colors.WarningLight
-			borderColor = kit.Colors.Warning
-			iconColor = kit.Colors.Warning
-			icon = "⚠"
-		case AlertError:
-			bg = kit.Colors.ErrorLight
-			borderColor = kit.Colors.Error
-			iconColor = kit.Colors.Error
-			icon = "✗"
-		}
-
-		return widget.Border{
-			Color:        borderColor,
-			CornerRadius: RadiusMedium,
-			Width:        unit.Dp(1),
-		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			defer clip.RRect{
-				Rect: image.Rectangle{Max: gtx.Constraints.Max},
-				NW:   int(RadiusMedium), NE: int(RadiusMedium),
-				SE: int(RadiusMedium), SW: int(RadiusMedium),
-			}.Push(gtx.Ops).Pop()
-
-			paint.Fill(gtx.Ops, bg)
-
-			return layout.UniformInset(kit.Spacing.Medium).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return layout.Flex{Alignment: layout.Start}.Layout(gtx,
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						iconLabel := material.Label(kit.Theme, unit.Sp(20), icon)
-						iconLabel.Color = iconColor
-						return iconLabel.Layout(gtx)
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return layout.Spacer{Width: kit.Spacing.Medium}.Layout(gtx)
-					}),
-					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								if title == "" {
-									return layout.Dimensions{}
-								}
-								titleLabel := material.Label(kit.Theme, kit.Typography.LabelMedium.Size, title)
-								titleLabel.Color = kit.Colors.OnSurface
-								return titleLabel.Layout(gtx)
-							}),
-							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								if title != "" {
-									return layout.Spacer{Height: kit.Spacing.Tiny}.Layout(gtx)
-								}
-								return layout.Dimensions{}
-							}),
-							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-								messageLabel := material.Label(kit.Theme, kit.Typography.BodyMedium.Size, message)
-								messageLabel.Color = kit.Colors.OnSurface
-								return messageLabel.Layout(gtx)
-							}),
-						)
-					}),
-				)
-			})
-		})
-	}
-}
-
-// Progress bar component
-func (kit *UIKit) ProgressBar(progress float32) layout.Widget {
-	return func(gtx layout.Context) layout.Dimensions {
-		// Clamp progress between 0 and 1
-		if progress < 0 {
-			progress = 0
-		}
-		if progress > 1 {
-			progress = 1
-		}
-
-		return widget.Border{
-			Color:        kit.Colors.BorderLight,
-			CornerRadius: unit.Dp(4),
-			Width:        unit.Dp(0),
-		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			// Background
-			defer clip.RRect{
-				Rect: image.Rectangle{Max: gtx.Constraints.Max},
-				NW:   4, NE: 4, SE: 4, SW: 4,
-			}.Push(gtx.Ops).Pop()
-
-			paint.Fill(gtx.Ops, kit.Colors.Gray200)
-
-			// Progress fill
-			progressWidth := int(float32(gtx.Constraints.Max.X) * progress)
-			if progressWidth > 0 {
-				defer clip.RRect{
-					Rect: image.Rectangle{Max: image.Point{X: progressWidth, Y: gtx.Constraints.Max.Y}},
-					NW:   4, NE: 4, SE: 4, SW: 4,
-				}.Push(gtx.Ops).Pop()
-
-				paint.Fill(gtx.Ops, kit.Colors.Primary500)
-			}
-
-			return layout.Dimensions{
-				Size: image.Point{X: gtx.Constraints.Max.X, Y: int(kit.Spacing.Small)},
-			}
-		})
-	}
-}
-
 // Helper function to create consistent spacing
 func (kit *UIKit) Space(size unit.Dp) layout.Widget {
 	return func(gtx layout.Context) layout.Dimensions {
@@ -655,8 +615,24 @@ func (kit *UIKit) Space(size unit.Dp) layout.Widget {
 // Helper function for consistent text styles
 func (kit *UIKit) Text(text string, style TypographyStyle, color color.NRGBA) layout.Widget {
 	return func(gtx layout.Context) layout.Dimensions {
-		label := material.Label(kit.Theme, style.Size, text)
-		label.Color = color
-		return label.Layout(gtx)
+		return kit.Label(text, style, color).Layout(gtx)
+	}
+}
+
+// Label returns a material label carrying the size, line height and font of style
+func (kit *UIKit) Label(text string, style TypographyStyle, color color.NRGBA) material.LabelStyle {
+	label := material.Label(kit.Theme, style.Size, text)
+	label.Color = color
+	label.LineHeight = style.LineHeight
+	label.Font = kit.font(style)
+	return label
+}
+
+// font resolves style's font, falling back to the theme's face
+func (kit *UIKit) font(style TypographyStyle) font.Font {
+	f := style.Font()
+	if f.Typeface == "" {
+		f.Typeface = kit.Theme.Face
 	}
+	return f
 }